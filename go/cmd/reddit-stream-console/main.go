@@ -1,27 +1,58 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+	_ "time/tzdata" // named timezones on systems without a zoneinfo database
 
 	"github.com/fenneh/reddit-stream-console/internal/app"
 	"github.com/fenneh/reddit-stream-console/internal/config"
-	"github.com/fenneh/reddit-stream-console/internal/reddit"
 	"github.com/fenneh/reddit-stream-console/internal/theme"
+	"github.com/fenneh/reddit-stream-console/reddit"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		path := ""
+		if len(os.Args) > 2 {
+			path = os.Args[2]
+		}
+		os.Exit(runValidate(path))
+	}
+
 	diag := false
-	for _, arg := range os.Args[1:] {
-		if arg == "--diag" || arg == "-diag" {
+	profile := ""
+	args := os.Args[1:]
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--diag" || arg == "-diag":
 			diag = true
+		case arg == "--profile" || arg == "-profile":
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") {
+				usageError("--profile needs a profile name")
+			}
+			i++
+			profile = args[i]
+		case strings.HasPrefix(arg, "--profile=") || strings.HasPrefix(arg, "-profile="):
+			_, profile, _ = strings.Cut(arg, "=")
+			if profile == "" {
+				usageError("--profile needs a profile name")
+			}
 		}
 	}
 
 	_ = config.LoadDotEnv(".env")
+	if profile == "" {
+		profile = os.Getenv(config.ProfileEnv)
+	}
+	if err := config.CheckProfileName(profile); err != nil {
+		usageError(err.Error())
+	}
 
 	appConfig, appConfigErr := config.LoadAppConfig("config/app_config.json")
 	if appConfig.DebugLogging {
@@ -31,9 +62,25 @@ func main() {
 		}
 	}
 
-	menuConfig, err := config.LoadMenuConfig("config/menu_config.json")
+	menuPath := config.ProfileMenuPath(profile)
+	if profile != "" && config.ResolveConfigPath(menuPath) == "" {
+		fmt.Fprintf(os.Stderr, "profile %q not found: no %s in the config search paths\n", profile, menuPath)
+		if names := config.Profiles(); len(names) > 0 {
+			fmt.Fprintf(os.Stderr, "available profiles: %s\n", strings.Join(names, ", "))
+		}
+		os.Exit(1)
+	}
+	menuConfig, err := config.LoadMenuConfig(menuPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to load menu config: %v\n", err)
+		fmt.Fprintf(os.Stderr, "failed to load menu config %s: %v\n", config.ResolveConfigPath(menuPath), err)
+		os.Exit(1)
+	}
+	// A broken menu would start with unusable items, so stop here and say
+	// which item and field to fix
+	menuIssues := config.ValidateMenuConfig(menuConfig)
+	if menuIssues.HasErrors() {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", emptyAsDash(config.ResolveConfigPath(menuPath)), menuIssues.Err())
+		fmt.Fprintf(os.Stderr, "Fix the file above, then check it with: %s validate\n", filepath.Base(os.Args[0]))
 		os.Exit(1)
 	}
 
@@ -43,22 +90,53 @@ func main() {
 	}
 
 	resolvedTheme, themeOK := theme.Lookup(appConfig.Theme)
-	var themeWarning string
+	var warnings []string
+	for _, issue := range menuIssues {
+		warnings = append(warnings, filepath.Base(menuPath)+": "+issue.String())
+	}
 	if !themeOK {
-		themeWarning = fmt.Sprintf("Unknown theme %q — using %q. Available: %s",
-			appConfig.Theme, resolvedTheme.Name, strings.Join(theme.Names(), ", "))
-		fmt.Fprintln(os.Stderr, themeWarning)
+		warnings = append(warnings, fmt.Sprintf("Unknown theme %q — using %q. Available: %s",
+			appConfig.Theme, resolvedTheme.Name, strings.Join(theme.Names(), ", ")))
+	}
+	if _, err := appConfig.KeyBindings(); err != nil {
+		warnings = append(warnings, fmt.Sprintf("%v (actions: %s)", err, strings.Join(config.KeyActions(), ", ")))
+	}
+	if resolvedTheme, err = theme.Override(resolvedTheme, appConfig.ThemeColors); err != nil {
+		warnings = append(warnings, fmt.Sprintf("%v (valid keys: %s)", err, strings.Join(theme.Roles(), ", ")))
+	}
+
+	client := reddit.NewClient(userAgent)
+	client.SetUserAgents(appConfig.UserAgents)
+	client.SetCredentials(os.Getenv("REDDIT_CLIENT_ID"), os.Getenv("REDDIT_CLIENT_SECRET"))
+	client.SetMaxDepth(appConfig.MaxCommentDepth)
+	client.SetTimeout(time.Duration(appConfig.TimeoutSeconds) * time.Second)
+	client.SetSearchTimeout(time.Duration(appConfig.SearchTimeoutSeconds) * time.Second)
+	client.SetAllowedSubreddits(appConfig.AllowedSubreddits)
+	if dir := config.DataDir(); appConfig.OfflineCache && dir != "" {
+		client.SetCacheDir(filepath.Join(dir, "cache"))
+	}
+	if err := client.SetProxy(appConfig.ProxyURL); err != nil {
+		warnings = append(warnings, fmt.Sprintf("Invalid proxy_url — using HTTP_PROXY/HTTPS_PROXY if set: %v", err))
+	}
+	if err := client.SetTimezone(appConfig.Timezone); err != nil {
+		warnings = append(warnings, fmt.Sprintf("Invalid timezone — using local time: %v", err))
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, warning)
 	}
 
 	if diag {
-		printDiagnostics(appConfig, appConfigErr, resolvedTheme)
+		printDiagnostics(appConfig, appConfigErr, resolvedTheme, client)
 		return
 	}
 
-	client := reddit.NewClient(userAgent)
-	tviewApp := app.NewTviewApp(menuConfig.MenuItems, client, resolvedTheme)
-	if themeWarning != "" {
-		tviewApp.SetStartupNotice(themeWarning)
+	tviewApp := app.NewTviewApp(menuConfig.MenuItems, client, resolvedTheme, appConfig)
+	tviewApp.SetMenuConfigPath(menuPath)
+	if config.FirstRun() {
+		tviewApp.ShowWelcome()
+	}
+	if len(warnings) > 0 {
+		tviewApp.SetStartupNotice(strings.Join(warnings, "  •  "))
 	}
 
 	if err := tviewApp.Run(); err != nil {
@@ -67,7 +145,7 @@ func main() {
 	}
 }
 
-func printDiagnostics(appConfig config.AppConfig, appConfigErr error, resolved theme.Theme) {
+func printDiagnostics(appConfig config.AppConfig, appConfigErr error, resolved theme.Theme, client *reddit.Client) {
 	exe, _ := os.Executable()
 	fmt.Println("reddit-stream-console diagnostics")
 	fmt.Println("=================================")
@@ -87,9 +165,15 @@ func printDiagnostics(appConfig config.AppConfig, appConfigErr error, resolved t
 	fmt.Printf("app_config.json error    : %v\n", appConfigErr)
 	fmt.Printf("menu_config.json resolved: %s\n", emptyAsDash(config.ResolveConfigPath("config/menu_config.json")))
 	fmt.Println()
+	fmt.Printf("timezone        : %q\n", appConfig.Timezone)
 	fmt.Printf("theme requested : %q\n", appConfig.Theme)
 	fmt.Printf("theme resolved  : %s\n", resolved.Name)
 	fmt.Printf("available themes: %s\n", strings.Join(theme.Names(), ", "))
+	if err := client.Authenticate(context.Background()); err != nil {
+		fmt.Printf("reddit auth     : %s (%v)\n", client.AuthState(), err)
+	} else {
+		fmt.Printf("reddit auth     : %s\n", client.AuthState())
+	}
 	fmt.Println()
 	fmt.Println("environment:")
 	for _, name := range []string{"TERM", "COLORTERM", "TERM_PROGRAM", "SSH_CONNECTION", "WT_SESSION"} {
@@ -103,6 +187,46 @@ func printDiagnostics(appConfig config.AppConfig, appConfigErr error, resolved t
 	}
 }
 
+// runValidate loads a menu config, prints every issue found, and returns
+// the process exit code: 0 when valid (warnings allowed), 1 otherwise.
+func runValidate(path string) int {
+	if path == "" {
+		path = config.ResolveConfigPath("config/menu_config.json")
+		if path == "" {
+			fmt.Println("No menu_config.json found in the config search paths; built-in defaults are used.")
+			return 0
+		}
+	} else if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if !fileExists(path) {
+		fmt.Fprintf(os.Stderr, "%s: file not found\n", path)
+		return 1
+	}
+
+	cfg, err := config.LoadMenuConfig(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return 1
+	}
+
+	issues := config.ValidateMenuConfig(cfg)
+	fmt.Printf("%s: %d menu items, %d issues\n", path, len(cfg.MenuItems), len(issues))
+	for _, issue := range issues {
+		level := "error"
+		if issue.Warning {
+			level = "warning"
+		}
+		fmt.Printf("  %-7s  %s\n", level, issue)
+	}
+	if issues.HasErrors() {
+		fmt.Println("INVALID")
+		return 1
+	}
+	fmt.Println("OK")
+	return 0
+}
+
 func fileExists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
@@ -119,3 +243,9 @@ func mustWD() string {
 	wd, _ := os.Getwd()
 	return wd
 }
+
+// usageError reports a bad command line and exits with status 2.
+func usageError(msg string) {
+	fmt.Fprintf(os.Stderr, "%s\nusage: %s [--diag] [--profile name] | validate [path]\n", msg, filepath.Base(os.Args[0]))
+	os.Exit(2)
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// authBadgeWidth fits the longest AuthState label plus a space each side.
+var authBadgeWidth = len(reddit.AuthAuthenticated.String()) + 2
+
+// writeHeader draws title on the left of the header and the Reddit auth
+// state in the right-aligned badge next to it.
+func (ta *TviewApp) writeHeader(title string) {
+	ta.headerTitle = title
+	ta.header.Clear()
+	fmt.Fprintf(ta.header, " [::b]%s[::-]", title)
+
+	state := ta.client.AuthState()
+	label := state.String()
+	color := ta.theme.Muted.Hex
+	switch state {
+	case reddit.AuthAuthenticated:
+		color = ta.theme.Accent.Hex
+	case reddit.AuthExpired:
+		color = ta.theme.Secondary.Hex
+	}
+	ta.authBadge.Clear()
+	fmt.Fprintf(ta.authBadge, "[%s]%s[-] ", color, label)
+}
+
+// watchAuth reports authentication changes from the client, e.g. an OAuth
+// token expiring, so rate-limit behaviour is never a mystery.
+func (ta *TviewApp) watchAuth() {
+	ta.client.OnAuthChange(func(state reddit.AuthState) {
+		ta.app.QueueUpdateDraw(func() {
+			ta.writeHeader(ta.headerTitle)
+			ta.setStatus(fmt.Sprintf("Reddit: %s", state))
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+type authorCount struct {
+	name  string
+	count int
+}
+
+// countAuthors returns the authors in comments ordered by comment count
+// (most active first), then by name.
+func countAuthors(comments []reddit.Comment) []authorCount {
+	counts := make(map[string]int)
+	for _, c := range comments {
+		counts[c.Author]++
+	}
+	authors := make([]authorCount, 0, len(counts))
+	for name, count := range counts {
+		authors = append(authors, authorCount{name: name, count: count})
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].count != authors[j].count {
+			return authors[i].count > authors[j].count
+		}
+		return authors[i].name < authors[j].name
+	})
+	return authors
+}
+
+// showAuthorPicker lists the authors of the comments shown and jumps to the
+// chosen author's most recent comment.
+func (ta *TviewApp) showAuthorPicker() {
+	authors := countAuthors(ta.visibleComments(ta.comments))
+	if len(authors) == 0 {
+		ta.setStatus("No comments loaded")
+		return
+	}
+
+	items := make([]pickerItem, len(authors))
+	for i, a := range authors {
+		items[i] = pickerItem{label: a.name, detail: fmt.Sprintf("%d", a.count)}
+	}
+	ta.showPicker("Authors", items, func(idx int) {
+		ta.jumpToAuthor(authors[idx].name, authors[idx].count)
+	})
+}
+
+func (ta *TviewApp) jumpToAuthor(name string, count int) {
+	var latest *reddit.Comment
+	visible := ta.visibleComments(ta.comments)
+	for i := range visible {
+		c := &visible[i]
+		if c.Author != name {
+			continue
+		}
+		if _, shown := ta.lineOf(c.ID); !shown {
+			continue
+		}
+		if latest == nil || c.CreatedUTC > latest.CreatedUTC {
+			latest = c
+		}
+	}
+	if latest == nil {
+		ta.setStatus(fmt.Sprintf("No visible comments by %s", name))
+		return
+	}
+	ta.selectComment(latest.ID)
+	ta.setStatus(fmt.Sprintf("%s — %d comments, jumped to the latest", name, count))
+}
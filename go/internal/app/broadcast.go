@@ -0,0 +1,50 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// broadcastSorts are the comment orders given to the primary and secondary
+// panes when a thread is broadcast to both.
+var broadcastSorts = []reddit.CommentSort{reddit.SortNew, reddit.SortTop}
+
+// toggleBroadcast switches broadcast mode, where selecting a thread in
+// either pane loads it into both panes with a different sort each.
+func (ta *TviewApp) toggleBroadcast() {
+	if ta.masterOf(ta.primaryPane) != nil || ta.masterOf(ta.secondaryPane) != nil {
+		ta.setStatus("Broadcast isn't available while a thread list drives the split")
+		return
+	}
+	ta.broadcast = !ta.broadcast
+	ta.updateSplitHeader()
+	if !ta.broadcast {
+		ta.setStatus("Broadcast off")
+		return
+	}
+	labels := make([]string, len(broadcastSorts))
+	for i, s := range broadcastSorts {
+		labels[i] = s.Label()
+	}
+	ta.setStatus(fmt.Sprintf("Broadcast on — selecting a thread opens it in both panes (%s)", strings.Join(labels, " / ")))
+
+	// Fan out straight away when a pane already shows a thread
+	if pane := ta.getActivePane(); pane != nil && pane.thread != nil {
+		ta.broadcastThread(*pane.thread, pane.threadMenu)
+	}
+}
+
+// broadcastThread loads thread, listed by menu, into every pane, one sort
+// per pane.
+func (ta *TviewApp) broadcastThread(thread reddit.Thread, menu *config.MenuItem) {
+	for i, pane := range []*CommentPane{ta.primaryPane, ta.secondaryPane} {
+		if pane == nil {
+			continue
+		}
+		pane.sort = broadcastSorts[i%len(broadcastSorts)]
+		ta.loadPaneThread(pane, thread, menu)
+	}
+}
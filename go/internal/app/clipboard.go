@@ -0,0 +1,173 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+var (
+	errNoClipboard  = errors.New("no clipboard tool available")
+	errOSC52TooLong = errors.New("too long to copy through the terminal (OSC 52)")
+)
+
+// osc52Max caps the text sent through OSC 52; terminals drop sequences much
+// over 100 KB once base64-encoded.
+const osc52Max = 74 * 1024
+
+// copyToClipboard pipes text into the platform's clipboard tool. On Linux it
+// tries wl-copy, xclip and xsel in turn; errNoClipboard is returned when none
+// is installed or there is no display to own the selection. Over SSH the
+// text goes to the local terminal's clipboard through the screen instead.
+func (ta *TviewApp) copyToClipboard(text string) error {
+	if os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "" {
+		return copyOSC52(ta.screen, text)
+	}
+
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		if os.Getenv("DISPLAY") != "" {
+			candidates = append(candidates,
+				[]string{"xclip", "-selection", "clipboard"},
+				[]string{"xsel", "--clipboard", "--input"})
+		}
+	}
+
+	for _, c := range candidates {
+		path, err := exec.LookPath(c[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, c[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+	return errNoClipboard
+}
+
+// copyOSC52 asks the terminal to set its clipboard (OSC 52). It goes
+// through screen so the escape is not interleaved with a frame being drawn;
+// a terminal without clipboard support ignores it.
+func copyOSC52(screen tcell.Screen, text string) error {
+	if len(text) > osc52Max {
+		return errOSC52TooLong
+	}
+	if screen == nil {
+		return errNoClipboard
+	}
+	screen.SetClipboard([]byte(text))
+	return nil
+}
+
+// captureScreen keeps the screen tview draws on for copyOSC52.
+func (ta *TviewApp) captureScreen() {
+	ta.app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		ta.screen = screen
+		return false
+	})
+}
+
+// copySelected copies the selected comment's text, or the thread's link
+// when no comment is selected.
+func (ta *TviewApp) copySelected() {
+	idx, ok := ta.selectedComment()
+	if !ok {
+		ta.copyThreadLink()
+		return
+	}
+	c := ta.comments[idx]
+	if err := ta.copyToClipboard(c.Body); err != nil {
+		ta.setStatus(copyFailedStatus(err))
+		return
+	}
+	ta.setStatus(fmt.Sprintf("Copied comment by u/%s", c.Author))
+}
+
+// copyFailedStatus explains why copyToClipboard failed.
+func copyFailedStatus(err error) string {
+	switch {
+	case errors.Is(err, errOSC52TooLong):
+		return "Can't copy here — " + err.Error()
+	case errors.Is(err, errNoClipboard):
+		return "Can't copy here — no clipboard tool (install wl-copy, xclip or xsel)"
+	default:
+		return fmt.Sprintf("Copy failed: %v", err)
+	}
+}
+
+// copyThreadLink copies the current thread's permalink to the clipboard,
+// showing the link in the status bar instead when there is no clipboard.
+func (ta *TviewApp) copyThreadLink() {
+	if ta.currentThread == nil {
+		return
+	}
+	link := "https://reddit.com" + ta.currentThread.Permalink
+	if err := ta.copyToClipboard(link); err != nil {
+		ta.setStatus(fmt.Sprintf("Can't copy here — %s", link))
+		return
+	}
+	ta.setStatus(fmt.Sprintf("Copied %s", link))
+}
+
+// quoteSnippetLen caps the quoted part of a shared comment.
+const quoteSnippetLen = 200
+
+// commentShareText formats a comment for pasting into chat: its body
+// quoted (collapsed to one line and shortened) above its permalink.
+func commentShareText(threadPermalink string, c reddit.Comment) string {
+	snippet := strings.Join(strings.Fields(c.Body), " ")
+	if runes := []rune(snippet); len(runes) > quoteSnippetLen {
+		snippet = strings.TrimSpace(string(runes[:quoteSnippetLen-1])) + "…"
+	}
+	return fmt.Sprintf("> %s — u/%s\n%s", snippet, c.Author, commentPermalink(threadPermalink, c.ID))
+}
+
+// commentPermalink returns the permalink of comment id in a thread.
+func commentPermalink(threadPermalink, id string) string {
+	return "https://reddit.com" + strings.TrimSuffix(threadPermalink, "/") + "/" + id + "/"
+}
+
+// copyCommentLink copies the selected comment's permalink and shows it.
+func (ta *TviewApp) copyCommentLink() {
+	idx, ok := ta.selectedComment()
+	if !ok || ta.currentThread == nil {
+		ta.setStatus("No comment selected — use J/K to select one")
+		return
+	}
+	link := commentPermalink(ta.currentThread.Permalink, ta.comments[idx].ID)
+	if err := ta.copyToClipboard(link); err != nil {
+		ta.setStatus(fmt.Sprintf("Can't copy here — %s", link))
+		return
+	}
+	ta.setStatus("Copied " + link)
+}
+
+// copyCommentQuote copies the selected comment as a quote plus its link.
+func (ta *TviewApp) copyCommentQuote() {
+	idx, ok := ta.selectedComment()
+	if !ok || ta.currentThread == nil {
+		ta.setStatus("No comment selected — use J/K to select one")
+		return
+	}
+	c := ta.comments[idx]
+	if err := ta.copyToClipboard(commentShareText(ta.currentThread.Permalink, c)); err != nil {
+		ta.setStatus(fmt.Sprintf("Can't copy here — %s", commentPermalink(ta.currentThread.Permalink, c.ID)))
+		return
+	}
+	ta.setStatus(fmt.Sprintf("Copied quote from u/%s", c.Author))
+}
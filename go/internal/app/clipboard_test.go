@@ -0,0 +1,52 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestCommentShareText(t *testing.T) {
+	c := reddit.Comment{ID: "c9", Author: "bob", Body: "What a\n\n  goal!"}
+	got := commentShareText("/r/soccer/comments/abc/match_thread/", c)
+	want := "> What a goal! — u/bob\nhttps://reddit.com/r/soccer/comments/abc/match_thread/c9/"
+	if got != want {
+		t.Errorf("commentShareText = %q, want %q", got, want)
+	}
+}
+
+func TestCommentPermalink(t *testing.T) {
+	want := "https://reddit.com/r/soccer/comments/abc/match_thread/c9/"
+	for _, permalink := range []string{"/r/soccer/comments/abc/match_thread/", "/r/soccer/comments/abc/match_thread"} {
+		if got := commentPermalink(permalink, "c9"); got != want {
+			t.Errorf("commentPermalink(%q) = %q, want %q", permalink, got, want)
+		}
+	}
+}
+
+func TestCopyOSC52(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	if err := copyOSC52(screen, "hi"); err != nil {
+		t.Fatalf("copyOSC52: %v", err)
+	}
+	if got := string(screen.GetClipboardData()); got != "hi" {
+		t.Errorf("clipboard = %q, want %q", got, "hi")
+	}
+	if err := copyOSC52(nil, "hi"); !errors.Is(err, errNoClipboard) {
+		t.Errorf("without a screen err = %v, want errNoClipboard", err)
+	}
+}
+
+func TestOSC52TooLong(t *testing.T) {
+	err := copyOSC52(tcell.NewSimulationScreen(""), strings.Repeat("x", osc52Max+1))
+	if !errors.Is(err, errOSC52TooLong) {
+		t.Fatalf("err = %v, want errOSC52TooLong", err)
+	}
+	if got := copyFailedStatus(err); strings.Contains(got, "no clipboard tool") {
+		t.Errorf("status %q blames a missing clipboard tool", got)
+	}
+}
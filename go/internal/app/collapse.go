@@ -0,0 +1,165 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// isCollapsed reports whether the replies under id are hidden.
+func (s *commentViewState) isCollapsed(id string) bool {
+	return s.collapsed[id]
+}
+
+// expandAncestors uncollapses every ancestor of id so the comment is drawn.
+// It reports whether anything changed.
+func (s *commentViewState) expandAncestors(id string, comments []reddit.Comment) bool {
+	parents := make(map[string]string, len(comments))
+	for _, c := range comments {
+		parents[c.ID] = c.ParentID
+	}
+	changed := false
+	for p := parents[id]; p != ""; p = parents[p] {
+		if s.collapsed[p] {
+			delete(s.collapsed, p)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// countReplies returns the number of comments below node.
+func countReplies(node *commentNode) int {
+	n := len(node.children)
+	for _, child := range node.children {
+		n += countReplies(child)
+	}
+	return n
+}
+
+// treeOrder lists comment IDs in render order, ignoring collapse state.
+func treeOrder(roots []*commentNode) []string {
+	var ids []string
+	var walk func(nodes []*commentNode)
+	walk = func(nodes []*commentNode) {
+		for _, node := range nodes {
+			ids = append(ids, node.comment.ID)
+			walk(node.children)
+		}
+	}
+	walk(roots)
+	return ids
+}
+
+// hasReplies reports whether any loaded comment replies to id.
+func hasReplies(comments []reddit.Comment, id string) bool {
+	for _, c := range comments {
+		if c.ParentID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleCollapse hides or shows the replies of the selected comment. With
+// loadStub set (Enter), a comment whose replies are all behind a "load
+// more" stub fetches them instead, since there is nothing to collapse.
+func (ta *TviewApp) toggleCollapse(loadStub bool) {
+	idx, ok := ta.selectedComment()
+	if !ok {
+		ta.setStatus("No comment selected — use J/K to select one")
+		return
+	}
+	id := ta.comments[idx].ID
+	if loadStub && len(ta.comments[idx].MoreChildren) > 0 && !hasReplies(ta.visibleComments(ta.comments), id) {
+		ta.loadMore(id)
+		return
+	}
+	if ta.collapsed == nil {
+		ta.collapsed = make(map[string]bool)
+	}
+	if ta.collapsed[id] {
+		delete(ta.collapsed, id)
+	} else {
+		ta.collapsed[id] = true
+	}
+	ta.selectComment(id)
+}
+
+// nextMatch selects the next (delta > 0) or previous filter match in thread
+// order, wrapping around. Matches inside collapsed replies are revealed by
+// expanding their ancestors.
+func (ta *TviewApp) nextMatch(delta int) {
+	q := parseFilterQuery(ta.commentFilter)
+	q.withReplies = false // step through the matches themselves
+	if !q.active() {
+		ta.setStatus("No filter active — press / to search")
+		return
+	}
+	visible := ta.visibleComments(ta.comments)
+	ids := treeOrder(buildCommentTree(visible, q, ta.filterMatcher()))
+	if len(ids) == 0 {
+		ta.setStatus(fmt.Sprintf("No matches for %q", ta.commentFilter))
+		return
+	}
+
+	pos := -1
+	for i, id := range ids {
+		if id == ta.selectedID {
+			pos = i
+			break
+		}
+	}
+	switch {
+	case pos == -1 && delta > 0:
+		pos = 0
+	case pos == -1:
+		pos = len(ids) - 1
+	default:
+		pos = (pos + delta + len(ids)) % len(ids)
+	}
+
+	ta.expandAncestors(ids[pos], visible)
+	ta.selectComment(ids[pos])
+	ta.setStatus(fmt.Sprintf("Match %d/%d", pos+1, len(ids)))
+}
+
+// collapseAll hides the replies of every comment, leaving only the root
+// comments visible; expanding one then shows its direct replies collapsed.
+// A selection inside a hidden subtree moves to its root comment. Only the
+// comments shown count, so in catch-up mode a reply to an older comment
+// stays a root.
+func (ta *TviewApp) collapseAll() {
+	visible := ta.visibleComments(ta.comments)
+	parents := make(map[string]string, len(visible))
+	for _, c := range visible {
+		parents[c.ID] = c.ParentID
+	}
+	ta.collapsed = make(map[string]bool)
+	for _, c := range visible {
+		if _, shown := parents[c.ParentID]; shown {
+			ta.collapsed[c.ParentID] = true
+		} else {
+			parents[c.ID] = ""
+		}
+	}
+	for parents[ta.selectedID] != "" {
+		ta.selectedID = parents[ta.selectedID]
+	}
+	ta.refreshCollapseView(fmt.Sprintf("Collapsed replies under %d comments", len(ta.collapsed)))
+}
+
+// expandAll shows every reply again.
+func (ta *TviewApp) expandAll() {
+	ta.collapsed = nil
+	ta.refreshCollapseView("Expanded all replies")
+}
+
+func (ta *TviewApp) refreshCollapseView(status string) {
+	if ta.selectedID != "" {
+		ta.selectComment(ta.selectedID)
+	} else {
+		ta.renderComments()
+	}
+	ta.setStatus(status)
+}
@@ -0,0 +1,263 @@
+package app
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// renderedComment records where a comment was drawn in a comments view.
+type renderedComment struct {
+	id    string
+	line  int
+	depth int
+}
+
+// commentViewState holds per-view browsing state that has to survive
+// re-renders: the post being shown, the selection cursor, the parent-jump
+// history, the line offset of every comment drawn by the last render, and
+// which comments have been seen (anything else is marked new), which
+// comments have their replies collapsed, and which were there when the
+// thread was opened.
+type commentViewState struct {
+	post            reddit.Post
+	selfTextToggled bool // flips the configured self-text collapse default
+	selectedID      string
+	jumpStack       []string
+	rendered        []renderedComment
+	seen            map[string]bool
+	arrived         map[string]time.Time // when each unseen comment first appeared
+	collapsed       map[string]bool
+	opened          map[string]bool  // IDs of the first load, kept across refreshes
+	sinceOpen       bool             // show only comments missing from opened
+	moreLoaded      []reddit.Comment // fetched through "load more" stubs
+	loadingMore     bool
+	lowered         lowerCache       // lowercased comment text for the filter
+	keywords        *regexp.Regexp   // highlight_keywords for this thread, nil for none
+	threadMenu      *config.MenuItem // menu item the thread was listed by, nil when opened by URL
+}
+
+func (s *commentViewState) lineOf(id string) (int, bool) {
+	for _, rc := range s.rendered {
+		if rc.id == id {
+			return rc.line, true
+		}
+	}
+	return 0, false
+}
+
+func (s *commentViewState) indexOf(id string) int {
+	for i, rc := range s.rendered {
+		if rc.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// lineCounter wraps a writer and counts the newlines written through it so
+// the renderer can record the line each comment starts on.
+type lineCounter struct {
+	w     io.Writer
+	lines int
+}
+
+func (lc *lineCounter) Write(p []byte) (int, error) {
+	lc.lines += strings.Count(string(p), "\n")
+	return lc.w.Write(p)
+}
+
+// marginWriter prefixes every line written through it with margin, used to
+// centre a width-capped comment column.
+type marginWriter struct {
+	w       io.Writer
+	margin  string
+	midLine bool
+}
+
+func (mw *marginWriter) Write(p []byte) (int, error) {
+	var b strings.Builder
+	for _, r := range string(p) {
+		if !mw.midLine && r != '\n' {
+			b.WriteString(mw.margin)
+			mw.midLine = true
+		}
+		b.WriteRune(r)
+		if r == '\n' {
+			mw.midLine = false
+		}
+	}
+	if _, err := io.WriteString(mw.w, b.String()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// moveCursor moves the comment selection by delta in render order. With no
+// selection it starts from the first comment at or below the top of the view.
+func (ta *TviewApp) moveCursor(delta int) {
+	if len(ta.rendered) == 0 {
+		return
+	}
+
+	idx := ta.indexOf(ta.selectedID)
+	if idx == -1 {
+		row, _ := ta.commentsView.GetScrollOffset()
+		idx = len(ta.rendered) - 1
+		for i, rc := range ta.rendered {
+			if rc.line >= row {
+				idx = i
+				break
+			}
+		}
+	} else {
+		idx += delta
+	}
+
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(ta.rendered) {
+		idx = len(ta.rendered) - 1
+	}
+	ta.selectComment(ta.rendered[idx].id)
+}
+
+// selectComment moves the cursor to id, re-renders, and scrolls the comment
+// into view if it is outside the visible area.
+func (ta *TviewApp) selectComment(id string) {
+	ta.selectedID = id
+	ta.renderComments()
+	ta.scrollToSelected()
+	if idx, ok := ta.selectedComment(); ok {
+		if detail := editedDetail(ta.comments[idx], ta.client.Location()); detail != "" {
+			ta.setStatus(detail)
+		}
+	}
+}
+
+func (ta *TviewApp) scrollToSelected() {
+	line, ok := ta.lineOf(ta.selectedID)
+	if !ok {
+		return
+	}
+	row, _ := ta.commentsView.GetScrollOffset()
+	_, _, _, height := ta.commentsView.GetInnerRect()
+	if line < row || height <= 0 || line >= row+height-1 {
+		ta.commentsView.ScrollTo(line, 0)
+	}
+}
+
+// selectedComment returns the comment under the cursor, if any.
+func (ta *TviewApp) selectedComment() (int, bool) {
+	if ta.selectedID == "" {
+		return -1, false
+	}
+	for i := range ta.comments {
+		if ta.comments[i].ID == ta.selectedID {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// jumpToParent selects the parent of the selected comment, remembering the
+// current one so jumpBack can return to it.
+func (ta *TviewApp) jumpToParent() {
+	idx, ok := ta.selectedComment()
+	if !ok {
+		ta.setStatus("No comment selected — use J/K to select one")
+		return
+	}
+	parentID := ta.comments[idx].ParentID
+	if parentID == "" {
+		ta.setStatus("Top-level comment has no parent")
+		return
+	}
+	if _, ok := ta.lineOf(parentID); !ok {
+		ta.setStatus("Parent comment is not shown (filtered or not loaded)")
+		return
+	}
+	ta.jumpStack = append(ta.jumpStack, ta.selectedID)
+	ta.selectComment(parentID)
+}
+
+// jumpBack returns to the comment selected before the last jumpToParent.
+func (ta *TviewApp) jumpBack() {
+	for len(ta.jumpStack) > 0 {
+		id := ta.jumpStack[len(ta.jumpStack)-1]
+		ta.jumpStack = ta.jumpStack[:len(ta.jumpStack)-1]
+		if _, ok := ta.lineOf(id); ok {
+			ta.selectComment(id)
+			return
+		}
+	}
+	ta.setStatus("No previous comment to return to")
+}
+
+// stepRoot returns the top-level comment after (delta > 0) or before the
+// one holding the selection, wrapping around. With nothing selected it
+// steps from the comment starting at row. The selection is preferred since
+// the view cannot scroll the last screen's roots to the top, so row stops
+// moving there.
+func (s *commentViewState) stepRoot(row, delta int) (renderedComment, bool) {
+	var roots []renderedComment
+	for _, rc := range s.rendered {
+		if rc.depth == 0 {
+			roots = append(roots, rc)
+		}
+	}
+	if len(roots) == 0 {
+		return renderedComment{}, false
+	}
+	if line, ok := s.lineOf(s.selectedID); ok {
+		cur := 0
+		for i, rc := range roots {
+			if rc.line <= line {
+				cur = i
+			}
+		}
+		return roots[((cur+delta)%len(roots)+len(roots))%len(roots)], true
+	}
+	if delta > 0 {
+		for _, rc := range roots {
+			if rc.line > row {
+				return rc, true
+			}
+		}
+		return roots[0], true
+	}
+	for i := len(roots) - 1; i >= 0; i-- {
+		if roots[i].line < row {
+			return roots[i], true
+		}
+	}
+	return roots[len(roots)-1], true
+}
+
+// jumpRoot scrolls the next (delta > 0) or previous top-level comment to
+// the top of the view and selects it.
+func (ta *TviewApp) jumpRoot(delta int) {
+	row, _ := ta.commentsView.GetScrollOffset()
+	rc, ok := ta.stepRoot(row, delta)
+	if !ok {
+		return
+	}
+	ta.selectedID = rc.id
+	ta.renderComments()
+	ta.commentsView.ScrollTo(rc.line, 0)
+}
+
+// stepComments is n/p: the next or previous filter match while a filter is
+// active, the next or previous top-level comment otherwise.
+func (ta *TviewApp) stepComments(delta int) {
+	if parseFilterQuery(ta.commentFilter).active() {
+		ta.nextMatch(delta)
+		return
+	}
+	ta.jumpRoot(delta)
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStepRoot(t *testing.T) {
+	st := commentViewState{rendered: []renderedComment{
+		{id: "a", line: 0, depth: 0},
+		{id: "a1", line: 3, depth: 1},
+		{id: "b", line: 6, depth: 0},
+		{id: "c", line: 10, depth: 0},
+	}}
+	cases := []struct {
+		row, delta int
+		want       string
+	}{
+		{0, 1, "b"},
+		{4, 1, "b"},
+		{6, 1, "c"},
+		{10, 1, "a"}, // wraps to the first
+		{10, -1, "b"},
+		{7, -1, "b"},
+		{0, -1, "c"}, // wraps to the last
+	}
+	for _, tc := range cases {
+		got, ok := st.stepRoot(tc.row, tc.delta)
+		if !ok || got.id != tc.want {
+			t.Errorf("stepRoot(%d, %d) = %q, want %q", tc.row, tc.delta, got.id, tc.want)
+		}
+	}
+	if _, ok := (&commentViewState{}).stepRoot(0, 1); ok {
+		t.Error("stepRoot with nothing rendered should report false")
+	}
+}
+
+// The last roots share the final screen, so the scroll offset stays put
+// while n moves between them; stepping follows the selection instead.
+func TestStepRootOnLastScreen(t *testing.T) {
+	st := commentViewState{rendered: []renderedComment{
+		{id: "a", line: 0, depth: 0},
+		{id: "b", line: 20, depth: 0},
+		{id: "c", line: 24, depth: 0},
+		{id: "c1", line: 26, depth: 1},
+		{id: "d", line: 28, depth: 0},
+	}}
+	const row = 18 // offset capped at len(lines)-height
+	var got []string
+	for i := 0; i < 4; i++ {
+		rc, ok := st.stepRoot(row, 1)
+		if !ok {
+			t.Fatal("stepRoot reported nothing rendered")
+		}
+		st.selectedID = rc.id
+		got = append(got, rc.id)
+	}
+	if want := "b c d a"; strings.Join(got, " ") != want {
+		t.Errorf("n from the last screen visited %v, want %s", got, want)
+	}
+
+	st.selectedID = "c1"
+	if rc, _ := st.stepRoot(row, -1); rc.id != "b" {
+		t.Errorf("p from a reply of c = %q, want b", rc.id)
+	}
+}
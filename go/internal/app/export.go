@@ -0,0 +1,220 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// largeExport is the size past which copying an export warns that the
+// paste may be unwieldy.
+const largeExport = 100 * 1024
+
+// maxExportName caps the title part of an exported file's name.
+const maxExportName = 60
+
+// exportComments writes the thread and the comment tree kept by q to w as
+// markdown, one nested bullet per comment. The zero filterQuery exports
+// every comment.
+func exportComments(w io.Writer, thread reddit.Thread, post reddit.Post, comments []reddit.Comment, q filterQuery, lowered lowerCache) error {
+	if err := writeMarkdownHeader(w, thread, post); err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	return writeMarkdownTree(w, buildCommentTree(comments, q, lowered))
+}
+
+// writeMarkdownHeader writes the thread's title, link and OP text.
+func writeMarkdownHeader(w io.Writer, thread reddit.Thread, post reddit.Post) error {
+	if _, err := fmt.Fprintf(w, "# %s\n\nhttps://reddit.com%s\n", thread.Title, thread.Permalink); err != nil {
+		return err
+	}
+	if text := strings.TrimSpace(post.SelfText); text != "" {
+		if _, err := fmt.Fprintf(w, "\n%s\n", text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMarkdownTree writes roots and their replies as nested bullets.
+func writeMarkdownTree(w io.Writer, roots []*commentNode) error {
+	var walk func(nodes []*commentNode, depth int) error
+	walk = func(nodes []*commentNode, depth int) error {
+		indent := strings.Repeat("  ", depth)
+		for _, node := range nodes {
+			c := node.comment
+			lines := strings.Split(strings.TrimSpace(c.Body), "\n")
+			if _, err := fmt.Fprintf(w, "%s- **%s** (%s, %s): %s\n", indent, c.Author, scoreLabel(c), c.FormattedTime, lines[0]); err != nil {
+				return err
+			}
+			for _, line := range lines[1:] {
+				if _, err := fmt.Fprintf(w, "%s  %s\n", indent, line); err != nil {
+					return err
+				}
+			}
+			if err := walk(node.children, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(roots, 0)
+}
+
+// exportFileName builds "<title>-<time><ext>" from a thread title, keeping
+// letters and digits and turning every other run of characters into a
+// single dash.
+func exportFileName(title, ext string, now time.Time) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	name := []rune(strings.TrimSuffix(b.String(), "-"))
+	if len(name) > maxExportName {
+		name = []rune(strings.TrimSuffix(string(name[:maxExportName]), "-"))
+	}
+	if len(name) == 0 {
+		name = []rune("thread")
+	}
+	return fmt.Sprintf("%s-%s%s", string(name), now.Format("20060102-150405"), ext)
+}
+
+// exportDir is export_dir from the config, with a leading ~ expanded, or
+// the exports folder in the data directory.
+func (ta *TviewApp) exportDir() string {
+	if dir := strings.TrimSpace(ta.cfg.ExportDir); dir != "" {
+		return expandHome(dir)
+	}
+	if dir := config.DataDir(); dir != "" {
+		return filepath.Join(dir, "exports")
+	}
+	return "."
+}
+
+// expandHome replaces a leading "~" or "~/" in path with the user's home
+// directory. Other paths, and ~ when there is no home, are returned as-is.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// saveThreadMarkdown writes the loaded comments, honouring the active
+// filter, to a markdown file in the export directory.
+func (ta *TviewApp) saveThreadMarkdown() {
+	if ta.currentThread == nil {
+		return
+	}
+	ta.saveExport(".md", func(w io.Writer) error {
+		return exportComments(w, *ta.currentThread, ta.post, ta.comments, parseFilterQuery(ta.commentFilter), ta.filterMatcher())
+	})
+}
+
+// saveThreadJSON writes every loaded comment to a JSON file in the export
+// directory, flat or nested per export_nested_json.
+func (ta *TviewApp) saveThreadJSON() {
+	if ta.currentThread == nil {
+		return
+	}
+	ta.saveExport(".json", func(w io.Writer) error {
+		return reddit.WriteExport(w, *ta.currentThread, ta.comments, ta.cfg.ExportNestedJSON)
+	})
+}
+
+// saveExport fills a new file in the export directory, named after the
+// current thread, with write and reports where it went.
+func (ta *TviewApp) saveExport(ext string, write func(io.Writer) error) {
+	dir := ta.exportDir()
+	path := filepath.Join(dir, exportFileName(ta.currentThread.Title, ext, time.Now()))
+	err := os.MkdirAll(dir, 0o755)
+	if err == nil {
+		path, err = writeExport(path, write)
+	}
+	if err != nil {
+		ta.setStatus(fmt.Sprintf("Export failed: %v", err))
+		return
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	ta.setStatus(fmt.Sprintf("Saved to %s", path))
+}
+
+// maxExportSuffix bounds the "-2", "-3"… tried when export names collide.
+const maxExportSuffix = 100
+
+// writeExport creates a new file at path, or at path with "-2", "-3"…
+// before the extension when it already exists, fills it with write and
+// returns the path used. Existing files are never overwritten.
+func writeExport(path string, write func(io.Writer) error) (string, error) {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for n := 1; n <= maxExportSuffix; n++ {
+		name := path
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d%s", base, n, ext)
+		}
+		f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if err := write(f); err != nil {
+			f.Close()
+			return "", err
+		}
+		return name, f.Close()
+	}
+	return "", fmt.Errorf("%s: too many exports with this name", path)
+}
+
+// copyThreadMarkdown copies the whole thread as nested markdown to the
+// clipboard.
+func (ta *TviewApp) copyThreadMarkdown() {
+	if ta.currentThread == nil {
+		return
+	}
+	var b strings.Builder
+	if err := exportComments(&b, *ta.currentThread, ta.post, ta.comments, filterQuery{}, nil); err != nil {
+		ta.setStatus(fmt.Sprintf("Export failed: %v", err))
+		return
+	}
+	if err := ta.copyToClipboard(b.String()); err != nil {
+		ta.setStatus(copyFailedStatus(err))
+		return
+	}
+	msg := fmt.Sprintf("Copied %d comments as markdown", len(ta.comments))
+	if b.Len() > largeExport {
+		msg += fmt.Sprintf(" — %d KB, a large paste", b.Len()/1024)
+	}
+	ta.setStatus(msg)
+}
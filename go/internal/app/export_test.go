@@ -0,0 +1,98 @@
+package app
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestExportCommentsNested(t *testing.T) {
+	comments := []reddit.Comment{
+		{ID: "a", Author: "ann", Body: "first\nsecond line", Score: 3, FormattedTime: "12:00"},
+		{ID: "b", Author: "bob", Body: "reply", ScoreHidden: true, FormattedTime: "12:01", ParentID: "a"},
+	}
+	var b strings.Builder
+	if err := exportComments(&b, reddit.Thread{Title: "Match", Permalink: "/r/x/comments/1/"}, reddit.Post{}, comments, filterQuery{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := "# Match\n\nhttps://reddit.com/r/x/comments/1/\n\n" +
+		"- **ann** (3 points, 12:00): first\n" +
+		"  second line\n" +
+		"  - **bob** (score hidden, 12:01): reply\n"
+	if b.String() != want {
+		t.Errorf("export =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestExportCommentsHonoursFilter(t *testing.T) {
+	comments := []reddit.Comment{
+		{ID: "a", Author: "ann", Body: "goal", Score: 1},
+		{ID: "b", Author: "bob", Body: "meh", Score: 1},
+	}
+	var b strings.Builder
+	if err := exportComments(&b, reddit.Thread{Title: "T"}, reddit.Post{}, comments, parseFilterQuery("goal"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "**ann**") || strings.Contains(b.String(), "**bob**") {
+		t.Errorf("filtered export = %q, want only ann", b.String())
+	}
+}
+
+func TestExportFileName(t *testing.T) {
+	now := time.Date(2024, 5, 1, 15, 4, 5, 0, time.UTC)
+	cases := map[string]string{
+		"Match Thread: Arsenal vs Spurs | Premier League": "match-thread-arsenal-vs-spurs-premier-league-20240501-150405.md",
+		"../../etc/passwd":            "etc-passwd-20240501-150405.md",
+		"???":                         "thread-20240501-150405.md",
+		"Bayern München 2-1 Dortmund": "bayern-münchen-2-1-dortmund-20240501-150405.md",
+		strings.Repeat("long ", 20):   strings.TrimSuffix(strings.Repeat("long-", 12), "-") + "-20240501-150405.md",
+	}
+	for title, want := range cases {
+		if got := exportFileName(title, ".md", now); got != want {
+			t.Errorf("exportFileName(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestWriteExportNeverOverwrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "thread-20240501-150405.md")
+	var got []string
+	for _, text := range []string{"first", "second", "third"} {
+		name, err := writeExport(path, func(w io.Writer) error {
+			_, err := io.WriteString(w, text)
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, filepath.Base(name))
+	}
+	want := []string{"thread-20240501-150405.md", "thread-20240501-150405-2.md", "thread-20240501-150405-3.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("export names = %v, want %v", got, want)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "first" {
+		t.Errorf("the first export was overwritten: %q", data)
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	if got, want := expandHome("~/exports"), filepath.Join(home, "exports"); got != want {
+		t.Errorf("expandHome(~/exports) = %q, want %q", got, want)
+	}
+	for _, path := range []string{"exports", "/tmp/x", "~user/x"} {
+		if got := expandHome(path); got != path {
+			t.Errorf("expandHome(%q) = %q, want it unchanged", path, got)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// Typing in the filter re-renders immediately on normal threads; past
+// filterDebounceAt comments the render waits until typing pauses for
+// filterDebounce.
+const (
+	filterDebounceAt = 1000
+	filterDebounce   = 150 * time.Millisecond
+)
+
+// loweredComment is a comment's author and body lowercased for matching,
+// with the body it was computed from so edits invalidate it.
+type loweredComment struct {
+	body        string
+	authorLower string
+	bodyLower   string
+}
+
+// lowerCache keeps the lowercased text of each comment by ID, so filtering
+// a large thread doesn't re-lowercase every comment on every keystroke.
+type lowerCache map[string]loweredComment
+
+// filterScope restricts which part of a comment the filter term is matched
+// against.
+type filterScope int
+
+const (
+	scopeAny filterScope = iota
+	scopeAuthor
+	scopeBody
+)
+
+// filterQuery is the parsed filter input. The syntax is an optional "+"
+// (keep the replies of matching comments), then an optional ">N" minimum
+// score, then an optional "author:" or "body:" scope, then the term:
+// "+author:bob" shows bob's comments and everything under them, ">50 var"
+// shows comments scoring at least 50 that mention var. Words after an
+// author name must also appear in the body: "author:bob goal".
+type filterQuery struct {
+	term        string // lowercased
+	text        string // lowercased body term alongside an author: scope
+	scope       filterScope
+	withReplies bool
+	minScore    int
+	hasMinScore bool
+}
+
+func parseFilterQuery(raw string) filterQuery {
+	var q filterQuery
+	s := strings.TrimSpace(raw)
+	if rest, ok := strings.CutPrefix(s, "+"); ok {
+		q.withReplies = true
+		s = strings.TrimSpace(rest)
+	}
+	if rest, ok := strings.CutPrefix(s, ">"); ok {
+		num, tail, _ := strings.Cut(strings.TrimSpace(rest), " ")
+		if n, err := strconv.Atoi(num); err == nil {
+			q.minScore, q.hasMinScore = n, true
+			s = strings.TrimSpace(tail)
+		}
+	}
+	lower := strings.ToLower(s)
+	for prefix, scope := range map[string]filterScope{"author:": scopeAuthor, "body:": scopeBody} {
+		if rest, ok := strings.CutPrefix(lower, prefix); ok {
+			q.scope = scope
+			lower = strings.TrimSpace(rest)
+			if scope == scopeAuthor {
+				name, text, _ := strings.Cut(lower, " ")
+				lower, q.text = name, strings.TrimSpace(text)
+			}
+			break
+		}
+	}
+	q.term = lower
+	return q
+}
+
+// active reports whether the query filters anything.
+func (q filterQuery) active() bool {
+	return q.term != "" || q.hasMinScore
+}
+
+// lowScore reports whether c falls below the query's minimum score.
+// Comments whose score Reddit is still hiding are kept.
+func (q filterQuery) lowScore(c reddit.Comment) bool {
+	return q.hasMinScore && !c.ScoreHidden && c.Score < q.minScore
+}
+
+// matches reports whether c matches q's term within its scope. A nil cache
+// still works but saves nothing.
+func (lc lowerCache) matches(c reddit.Comment, q filterQuery) bool {
+	e, ok := lc[c.ID]
+	if !ok || e.body != c.Body {
+		e = loweredComment{
+			body:        c.Body,
+			authorLower: strings.ToLower(c.Author),
+			bodyLower:   strings.ToLower(c.Body),
+		}
+		if lc != nil {
+			lc[c.ID] = e
+		}
+	}
+	if q.lowScore(c) {
+		return false
+	}
+	if q.term == "" {
+		return true
+	}
+	switch q.scope {
+	case scopeAuthor:
+		return strings.Contains(e.authorLower, q.term) && strings.Contains(e.bodyLower, q.text)
+	case scopeBody:
+		return strings.Contains(e.bodyLower, q.term)
+	}
+	return strings.Contains(e.authorLower, q.term) || strings.Contains(e.bodyLower, q.term)
+}
+
+// filterMatcher returns the state's lowercase cache, creating it on first
+// use.
+func (s *commentViewState) filterMatcher() lowerCache {
+	if s.lowered == nil {
+		s.lowered = lowerCache{}
+	}
+	return s.lowered
+}
+
+// filterChanged re-renders the comments for a new filter text, debounced
+// on large threads so fast typing doesn't queue a full render per key.
+func (ta *TviewApp) filterChanged(text string) {
+	before, after := parseFilterQuery(ta.commentFilter), parseFilterQuery(text)
+	ta.commentFilter = text
+	if before.hasMinScore != after.hasMinScore || before.minScore != after.minScore {
+		ta.updateHeader(ta.threadTitle(), commentsKeys)
+	}
+	if len(ta.comments) < filterDebounceAt {
+		ta.renderComments()
+		return
+	}
+	ta.filterSeq++
+	seq := ta.filterSeq
+	time.AfterFunc(filterDebounce, func() {
+		ta.app.QueueUpdateDraw(func() {
+			if seq == ta.filterSeq {
+				ta.renderComments()
+			}
+		})
+	})
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestLowerCacheMatchesAndTracksEdits(t *testing.T) {
+	lc := lowerCache{}
+	c := reddit.Comment{ID: "c1", Author: "GoonerFan", Body: "What a GOAL"}
+	if !lc.matches(c, parseFilterQuery("goal")) || !lc.matches(c, parseFilterQuery("gooner")) {
+		t.Error("expected case-insensitive match on body and author")
+	}
+	c.Body = "edited away"
+	if lc.matches(c, parseFilterQuery("goal")) {
+		t.Error("cache should be refreshed when the body changes")
+	}
+	if !lc.matches(c, parseFilterQuery("away")) {
+		t.Error("expected match on edited body")
+	}
+}
+
+func TestLowerCacheNil(t *testing.T) {
+	var lc lowerCache
+	if !lc.matches(reddit.Comment{ID: "c1", Body: "Hello"}, parseFilterQuery("hello")) {
+		t.Error("nil cache should still match")
+	}
+}
+
+func TestParseFilterQuery(t *testing.T) {
+	cases := map[string]filterQuery{
+		"Goal":                    {term: "goal"},
+		"author:Bob":              {term: "bob", scope: scopeAuthor},
+		"body: var":               {term: "var", scope: scopeBody},
+		"+ author:bob":            {term: "bob", scope: scopeAuthor, withReplies: true},
+		"  ":                      {},
+		"authored:pen":            {term: "authored:pen"},
+		">50":                     {minScore: 50, hasMinScore: true},
+		"+> 10 body:x":            {term: "x", scope: scopeBody, withReplies: true, minScore: 10, hasMinScore: true},
+		">0 Goal":                 {term: "goal", hasMinScore: true},
+		">lots":                   {term: ">lots"},
+		"author:Bob  What a Goal": {term: "bob", text: "what a goal", scope: scopeAuthor},
+	}
+	for in, want := range cases {
+		if got := parseFilterQuery(in); got != want {
+			t.Errorf("parseFilterQuery(%q) = %+v, want %+v", in, got, want)
+		}
+	}
+}
+
+func TestFilterScopes(t *testing.T) {
+	c := reddit.Comment{ID: "c1", Author: "goalmachine", Body: "what a save"}
+	var lc lowerCache
+	if lc.matches(c, parseFilterQuery("body:goal")) {
+		t.Error("body: should not match the author")
+	}
+	if !lc.matches(c, parseFilterQuery("author:goal")) {
+		t.Error("author: should match the author")
+	}
+	if lc.matches(c, parseFilterQuery("author:save")) {
+		t.Error("author: should not match the body")
+	}
+	if !lc.matches(c, parseFilterQuery("author:goal save")) {
+		t.Error("author: with a term should match author and body together")
+	}
+	if lc.matches(c, parseFilterQuery("author:goal miss")) {
+		t.Error("author: with a term should need the term in the body")
+	}
+}
+
+func TestBuildCommentTreeKeepsReplies(t *testing.T) {
+	comments := []reddit.Comment{
+		{ID: "a", Author: "x", Body: "goal!"},
+		{ID: "b", Author: "y", Body: "agreed", ParentID: "a"},
+		{ID: "c", Author: "z", Body: "nested", ParentID: "b"},
+		{ID: "d", Author: "w", Body: "unrelated"},
+	}
+	if got := treeOrder(buildCommentTree(comments, parseFilterQuery("goal"), nil)); len(got) != 1 {
+		t.Errorf("plain filter kept %v, want only a", got)
+	}
+	got := treeOrder(buildCommentTree(comments, parseFilterQuery("+goal"), nil))
+	if len(got) != 3 || got[0] != "a" || got[2] != "c" {
+		t.Errorf("+ filter kept %v, want [a b c]", got)
+	}
+}
+
+func TestBuildCommentTreeMinScore(t *testing.T) {
+	comments := []reddit.Comment{
+		{ID: "a", Body: "great goal", Score: 80},
+		{ID: "b", Body: "lol", Score: 2},
+		{ID: "c", Body: "goal reply", Score: 60, ParentID: "b"},
+		{ID: "d", Body: "fresh goal", ScoreHidden: true},
+		{ID: "e", Body: "goal", Score: 5, ParentID: "a"},
+	}
+	roots := buildCommentTree(comments, parseFilterQuery(">50"), nil)
+	if got := treeOrder(roots); len(got) != 3 || got[0] != "a" || got[1] != "c" || got[2] != "d" {
+		t.Errorf(">50 kept %v, want [a c d]", got)
+	}
+	if len(roots) != 3 {
+		t.Errorf("reply of a dropped comment should be a root, got %d roots", len(roots))
+	}
+	if got := treeOrder(buildCommentTree(comments, parseFilterQuery("+>50 great"), nil)); len(got) != 1 || got[0] != "a" {
+		t.Errorf("+>50 great kept %v, want [a] (low-score replies stay hidden)", got)
+	}
+}
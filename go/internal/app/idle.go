@@ -0,0 +1,47 @@
+package app
+
+import "time"
+
+// markInput records a keystroke for the idle auto-pause and, if refresh was
+// paused for idleness, resumes it with an immediate refresh.
+func (ta *TviewApp) markInput() {
+	ta.lastInput.Store(time.Now().UnixNano())
+	if ta.idlePaused {
+		ta.idlePaused = false
+		ta.resumeFromIdle()
+	}
+}
+
+// idle reports whether there has been no input for idle_pause_minutes.
+// It is safe to call from the refresh goroutines.
+func (ta *TviewApp) idle() bool {
+	if ta.cfg.IdlePauseMinutes <= 0 {
+		return false
+	}
+	limit := time.Duration(ta.cfg.IdlePauseMinutes) * time.Minute
+	return time.Since(time.Unix(0, ta.lastInput.Load())) >= limit
+}
+
+// pauseForIdle is called in place of a refresh tick while idle.
+func (ta *TviewApp) pauseForIdle() {
+	if ta.idlePaused {
+		return
+	}
+	ta.idlePaused = true
+	ta.setStatus("Auto-paused (idle) — press any key to resume refreshing")
+}
+
+// resumeFromIdle refreshes whatever was live before the idle pause; views
+// paused with f stay paused.
+func (ta *TviewApp) resumeFromIdle() {
+	if ta.splitMode {
+		for _, pane := range []*CommentPane{ta.primaryPane, ta.secondaryPane} {
+			if pane != nil && pane.refreshEnabled && !pane.refreshPaused && pane.thread != nil {
+				ta.loadCommentsForPane(pane)
+			}
+		}
+	} else if ta.refreshEnabled && !ta.refreshPaused {
+		ta.loadComments()
+	}
+	ta.setStatus("Auto-refresh resumed")
+}
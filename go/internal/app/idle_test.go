@@ -0,0 +1,26 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+)
+
+func TestIdle(t *testing.T) {
+	ta := &TviewApp{cfg: config.AppConfig{IdlePauseMinutes: 5}}
+	ta.lastInput.Store(time.Now().Add(-6 * time.Minute).UnixNano())
+	if !ta.idle() {
+		t.Error("expected idle after 6 minutes without input")
+	}
+	ta.markInput()
+	if ta.idle() {
+		t.Error("expected not idle right after input")
+	}
+
+	ta.cfg.IdlePauseMinutes = 0
+	ta.lastInput.Store(0)
+	if ta.idle() {
+		t.Error("idle_pause_minutes 0 should never pause")
+	}
+}
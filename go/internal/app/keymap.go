@@ -0,0 +1,145 @@
+package app
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+)
+
+// namedTcellKeys maps config.NamedKeys to the tcell key they stand for.
+// "space" is a rune, handled in parseBinding.
+var namedTcellKeys = map[string]tcell.Key{
+	"up":        tcell.KeyUp,
+	"down":      tcell.KeyDown,
+	"left":      tcell.KeyLeft,
+	"right":     tcell.KeyRight,
+	"enter":     tcell.KeyEnter,
+	"esc":       tcell.KeyEscape,
+	"tab":       tcell.KeyTab,
+	"backspace": tcell.KeyBackspace2,
+	"pgup":      tcell.KeyPgUp,
+	"pgdn":      tcell.KeyPgDn,
+	"home":      tcell.KeyHome,
+	"end":       tcell.KeyEnd,
+}
+
+// binding is one key: a rune when key is KeyRune, otherwise a special key.
+type binding struct {
+	key tcell.Key
+	r   rune
+}
+
+// keymap holds the keys bound to each rebindable action.
+type keymap map[string][]binding
+
+// newKeymap converts normalised key strings from config.KeyBindings.
+func newKeymap(bindings map[string][]string) keymap {
+	km := make(keymap, len(bindings))
+	for action, keys := range bindings {
+		for _, key := range keys {
+			if b, ok := parseBinding(key); ok {
+				km[action] = append(km[action], b)
+			}
+		}
+	}
+	return km
+}
+
+func parseBinding(key string) (binding, bool) {
+	if key == "space" {
+		return binding{key: tcell.KeyRune, r: ' '}, true
+	}
+	if k, ok := namedTcellKeys[key]; ok {
+		return binding{key: k}, true
+	}
+	if letter, ok := strings.CutPrefix(key, "ctrl+"); ok && len(letter) == 1 {
+		return binding{key: tcell.KeyCtrlA + tcell.Key(letter[0]-'a')}, true
+	}
+	if r := []rune(key); len(r) == 1 {
+		return binding{key: tcell.KeyRune, r: r[0]}, true
+	}
+	return binding{}, false
+}
+
+// match reports whether event triggers action.
+func (km keymap) match(event *tcell.EventKey, action string) bool {
+	for _, b := range km[action] {
+		if event.Key() != b.key {
+			continue
+		}
+		if b.key != tcell.KeyRune || event.Rune() == b.r {
+			return true
+		}
+	}
+	return false
+}
+
+// label names the keys bound to action for the help line, e.g. "Q" for
+// q and Q or "Ctrl+F,/". A letter bound in both cases is shown once, in
+// upper case.
+func (km keymap) label(action string) string {
+	bound := make(map[rune]bool)
+	for _, b := range km[action] {
+		if b.key == tcell.KeyRune {
+			bound[b.r] = true
+		}
+	}
+	var names []string
+	for _, b := range km[action] {
+		name := b.name()
+		if b.key == tcell.KeyRune && unicode.IsLetter(b.r) && bound[unicode.ToUpper(b.r)] && bound[unicode.ToLower(b.r)] {
+			if unicode.IsLower(b.r) {
+				continue
+			}
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ",")
+}
+
+func (b binding) name() string {
+	if b.key == tcell.KeyRune {
+		if b.r == ' ' {
+			return "Space"
+		}
+		return string(b.r)
+	}
+	for name, k := range namedTcellKeys {
+		if k == b.key {
+			return strings.ToUpper(name[:1]) + name[1:]
+		}
+	}
+	if b.key >= tcell.KeyCtrlA && b.key <= tcell.KeyCtrlZ {
+		return "Ctrl+" + string(rune('A'+b.key-tcell.KeyCtrlA))
+	}
+	return "?"
+}
+
+// keyHelp replaces each {action} in a header key list with the keys bound
+// to it, so the help follows the keybindings config.
+func (ta *TviewApp) keyHelp(keys string) string {
+	km := ta.keymap()
+	for _, action := range config.KeyActions() {
+		keys = strings.ReplaceAll(keys, "{"+action+"}", km.label(action))
+	}
+	return keys
+}
+
+// keymap returns the configured keybindings, building them on first use.
+func (ta *TviewApp) keymap() keymap {
+	if ta.keys == nil {
+		// Bad entries were reported at startup; the rest still apply.
+		bindings, _ := ta.cfg.KeyBindings()
+		ta.keys = newKeymap(bindings)
+	}
+	return ta.keys
+}
+
+// bound reports whether event triggers action under the configured
+// keybindings.
+func (ta *TviewApp) bound(event *tcell.EventKey, action string) bool {
+	return ta.keymap().match(event, action)
+}
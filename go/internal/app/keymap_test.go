@@ -0,0 +1,66 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestEveryNamedKeyParses(t *testing.T) {
+	for _, name := range config.NamedKeys() {
+		if _, ok := parseBinding(name); !ok {
+			t.Errorf("named key %q has no tcell mapping", name)
+		}
+	}
+}
+
+func TestKeymapMatch(t *testing.T) {
+	km := newKeymap(map[string][]string{
+		config.ActionMenuDown: {"n", "pgdn", "ctrl+n"},
+	})
+	cases := []struct {
+		event *tcell.EventKey
+		want  bool
+	}{
+		{tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModNone), true},
+		{tcell.NewEventKey(tcell.KeyRune, 'N', tcell.ModNone), false},
+		{tcell.NewEventKey(tcell.KeyPgDn, 0, tcell.ModNone), true},
+		{tcell.NewEventKey(tcell.KeyCtrlN, 0, tcell.ModCtrl), true},
+		{tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), false},
+	}
+	for _, c := range cases {
+		if got := km.match(c.event, config.ActionMenuDown); got != c.want {
+			t.Errorf("match(%s) = %v, want %v", c.event.Name(), got, c.want)
+		}
+	}
+}
+
+func TestReboundQuitKey(t *testing.T) {
+	ta := newKeyTestApp()
+	ta.cfg.Keybindings = map[string]config.StringOrSlice{config.ActionQuit: {"ctrl+q"}}
+
+	q := tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone)
+	if got := ta.globalKeyHandler(q); got != q {
+		t.Error("q should no longer quit once quit is rebound")
+	}
+	ctrlQ := tcell.NewEventKey(tcell.KeyCtrlQ, 0, tcell.ModCtrl)
+	if got := ta.globalKeyHandler(ctrlQ); got != nil {
+		t.Error("ctrl+q should quit")
+	}
+}
+
+func TestKeyHelpFollowsBindings(t *testing.T) {
+	ta := &TviewApp{}
+	if got := ta.keyHelp("{quit}:Quit  {back}:Back  {split_horizontal}/{split_vertical}:Split"); got != "Q:Quit  Esc:Back  H/V:Split" {
+		t.Errorf("default help = %q", got)
+	}
+
+	ta = &TviewApp{cfg: config.AppConfig{Keybindings: map[string]config.StringOrSlice{
+		config.ActionQuit:   {"ctrl+q"},
+		config.ActionFilter: {"f", "space"},
+	}}}
+	if got := ta.keyHelp("{quit}:Quit  {filter}:Filter"); got != "Ctrl+Q:Quit  f,Space:Filter" {
+		t.Errorf("rebound help = %q", got)
+	}
+}
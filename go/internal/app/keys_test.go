@@ -0,0 +1,44 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func newKeyTestApp() *TviewApp {
+	ta := &TviewApp{app: tview.NewApplication(), pages: tview.NewPages()}
+	ta.pages.AddPage("comments", tview.NewTextView(), true, true)
+	return ta
+}
+
+func TestQuitKeyPassesThroughInputs(t *testing.T) {
+	q := tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone)
+
+	cases := map[string]func(ta *TviewApp){
+		"url page": func(ta *TviewApp) {
+			ta.pages.AddPage("url", tview.NewInputField(), true, true)
+		},
+		"filter": func(ta *TviewApp) { ta.filterActive = true },
+		"prompt": func(ta *TviewApp) { ta.promptActive = true },
+		"focused input": func(ta *TviewApp) {
+			ta.app.SetFocus(tview.NewInputField())
+		},
+	}
+	for name, setup := range cases {
+		ta := newKeyTestApp()
+		setup(ta)
+		if got := ta.globalKeyHandler(q); got != q {
+			t.Errorf("%s: q was intercepted, want it passed to the input", name)
+		}
+	}
+}
+
+func TestQuitKeyHandledOutsideInputs(t *testing.T) {
+	ta := newKeyTestApp()
+	q := tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone)
+	if got := ta.globalKeyHandler(q); got != nil {
+		t.Error("q should be handled (quit) when no input is focused")
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+)
+
+// keywordPattern compiles the app-wide highlight_keywords plus those of
+// item (which may be nil) into one case-insensitive pattern, or nil when
+// there are none. Longer keywords come first so "red card" wins over
+// "red".
+func (ta *TviewApp) keywordPattern(item *config.MenuItem) *regexp.Regexp {
+	words := append([]string(nil), ta.cfg.HighlightKeywords...)
+	if item != nil {
+		words = append(words, item.HighlightKeywords...)
+	}
+	return compileKeywords(words)
+}
+
+func compileKeywords(words []string) *regexp.Regexp {
+	var quoted []string
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	sort.SliceStable(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	return regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+}
+
+// highlightKeywords wraps each whole-word match of re in line with a bold
+// color tag. It runs on lines that are already wrapped, so the tags never
+// count towards the wrap width; a phrase broken across two lines is not
+// highlighted.
+func highlightKeywords(line string, re *regexp.Regexp, color string) string {
+	if re == nil {
+		return line
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringIndex(line, -1) {
+		if !wordEdge(line, m[0], m[1]) {
+			continue
+		}
+		b.WriteString(line[last:m[0]])
+		fmt.Fprintf(&b, "[%s::b]%s[-:-:-]", color, line[m[0]:m[1]])
+		last = m[1]
+	}
+	if last == 0 {
+		return line
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+// wordEdge reports whether line[start:end] is not part of a longer word.
+func wordEdge(line string, start, end int) bool {
+	if r, _ := utf8.DecodeLastRuneInString(line[:start]); start > 0 && isWordRune(r) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(line[end:]); end < len(line) && isWordRune(r) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,23 @@
+package app
+
+import "testing"
+
+func TestHighlightKeywords(t *testing.T) {
+	re := compileKeywords([]string{"goal", "red card", "red", " ", "Man Utd"})
+	cases := []struct{ in, want string }{
+		{"GOAL! 1-0", "[#C::b]GOAL[-:-:-]! 1-0"},
+		{"goalkeeper and own-goal", "goalkeeper and own-[#C::b]goal[-:-:-]"},
+		{"straight red card", "straight [#C::b]red card[-:-:-]"},
+		{"reddish", "reddish"},
+		{"man utd are red", "[#C::b]man utd[-:-:-] are [#C::b]red[-:-:-]"},
+		{"nothing here", "nothing here"},
+	}
+	for _, c := range cases {
+		if got := highlightKeywords(c.in, re, "#C"); got != c.want {
+			t.Errorf("highlightKeywords(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+	if compileKeywords([]string{"", "  "}) != nil {
+		t.Error("blank keywords should give no pattern")
+	}
+}
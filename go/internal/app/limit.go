@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+)
+
+// Thread list size bounds for +/-. Reddit returns at most 100 results per
+// listing request.
+const (
+	defaultThreadLimit = 50
+	threadLimitStep    = 25
+	minThreadLimit     = 25
+	maxThreadLimit     = 100
+)
+
+// threadLimit returns the number of threads fetched for item.
+func threadLimit(item config.MenuItem) int {
+	if item.Limit == 0 {
+		return defaultThreadLimit
+	}
+	return item.Limit
+}
+
+// stepThreadLimit moves limit by delta steps, clamped to the allowed range.
+func stepThreadLimit(limit, delta int) int {
+	limit += delta * threadLimitStep
+	if limit < minThreadLimit {
+		limit = minThreadLimit
+	}
+	if limit > maxThreadLimit {
+		limit = maxThreadLimit
+	}
+	return limit
+}
+
+// adjustThreadLimit raises (delta > 0) or lowers the thread limit of the
+// current menu item for this session and re-runs the query.
+func (ta *TviewApp) adjustThreadLimit(delta int) {
+	if ta.currentMenu == nil {
+		return
+	}
+	current := threadLimit(*ta.currentMenu)
+	limit := stepThreadLimit(current, delta)
+	if limit == current {
+		ta.setStatus(fmt.Sprintf("Thread limit is already %d", limit))
+		return
+	}
+	ta.currentMenu.Limit = limit
+	ta.requeryThreads()
+}
+
+// requeryThreads fetches the thread list for the current menu item again.
+func (ta *TviewApp) requeryThreads() {
+	item := *ta.currentMenu
+	ta.setStatus(fmt.Sprintf("Loading up to %d threads...", threadLimit(item)))
+	ta.app.ForceDraw()
+
+	go func() {
+		threads, err := ta.fetchThreads(item)
+		ta.app.QueueUpdateDraw(func() {
+			if err != nil {
+				ta.loadFailed("load "+item.Title, err, ta.requeryThreads)
+				return
+			}
+			ta.loadSucceeded()
+			ta.threadsData = threads
+			ta.populateThreadList()
+			ta.showThreads()
+			ta.setStatus(fmt.Sprintf("%d threads (limit %d)", len(threads), threadLimit(item)))
+		})
+	}()
+}
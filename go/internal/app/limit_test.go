@@ -0,0 +1,23 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+)
+
+func TestStepThreadLimit(t *testing.T) {
+	cases := []struct{ limit, delta, want int }{
+		{threadLimit(config.MenuItem{}), 1, 75},
+		{75, 1, 100},
+		{100, 1, 100},
+		{50, -1, 25},
+		{25, -1, 25},
+		{10, 1, 35},
+	}
+	for _, c := range cases {
+		if got := stepThreadLimit(c.limit, c.delta); got != c.want {
+			t.Errorf("stepThreadLimit(%d, %d) = %d, want %d", c.limit, c.delta, got, c.want)
+		}
+	}
+}
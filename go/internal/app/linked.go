@@ -0,0 +1,52 @@
+package app
+
+// splitThreadList opens a master-detail split from the thread list: the
+// primary pane lists the threads and the secondary pane shows the comments
+// of whichever thread is selected, following the selection as it moves.
+func (ta *TviewApp) splitThreadList(direction int) {
+	if ta.splitMode || len(ta.threadsData) == 0 {
+		return
+	}
+
+	ta.stopAutoRefresh()
+	ta.splitMode = true
+	ta.splitDirection = direction
+
+	ta.primaryPane = NewCommentPane("primary", ta.theme)
+	ta.primaryPane.showingThreads = true
+	ta.primaryPane.threadsData = ta.threadsData
+	ta.primaryPane.threadIndex = ta.threadIndex
+	ta.primaryPane.currentMenu = ta.currentMenu
+
+	ta.secondaryPane = NewCommentPane("secondary", ta.theme)
+	ta.primaryPane.detail = ta.secondaryPane
+
+	ta.activePaneID = "primary"
+	ta.primaryPane.SetActive(true)
+	ta.secondaryPane.SetActive(false)
+
+	ta.followThreadSelection(ta.primaryPane)
+	ta.rebuildSplitLayout()
+	ta.pushNav("comments")
+	ta.pages.SwitchToPage("comments")
+	ta.updateMatchBar()
+}
+
+// followThreadSelection loads the selected thread of a linked thread-list
+// pane into its detail pane. It does nothing for unlinked panes.
+func (ta *TviewApp) followThreadSelection(pane *CommentPane) {
+	if pane.detail == nil || pane.threadIndex < 0 || pane.threadIndex >= len(pane.threadsData) {
+		return
+	}
+	ta.loadPaneThread(pane.detail, pane.threadsData[pane.threadIndex], pane.currentMenu)
+}
+
+// masterOf returns the thread-list pane linked to pane, or nil.
+func (ta *TviewApp) masterOf(pane *CommentPane) *CommentPane {
+	for _, p := range []*CommentPane{ta.primaryPane, ta.secondaryPane} {
+		if p != nil && p.detail == pane {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+var urlPattern = regexp.MustCompile(`https?://[^\s<>()\[\]"]+`)
+
+// commentLink is a URL found in a comment body.
+type commentLink struct {
+	url    string
+	author string
+}
+
+// extractLinks returns every distinct URL posted in comments, newest
+// comment first. Trailing sentence punctuation is not part of the URL.
+func extractLinks(comments []reddit.Comment) []commentLink {
+	ordered := make([]reddit.Comment, len(comments))
+	copy(ordered, comments)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].CreatedUTC > ordered[j].CreatedUTC
+	})
+
+	seen := make(map[string]bool)
+	var links []commentLink
+	for _, c := range ordered {
+		for _, match := range urlPattern.FindAllString(c.Body, -1) {
+			url := strings.TrimRight(match, ".,;:!?'*_")
+			if seen[url] {
+				continue
+			}
+			seen[url] = true
+			links = append(links, commentLink{url: url, author: c.Author})
+		}
+	}
+	return links
+}
+
+// showLinkPicker lists every link shared in the thread, led by the post's
+// own link for link posts, and opens the chosen one in the browser.
+func (ta *TviewApp) showLinkPicker() {
+	links := extractLinks(ta.comments)
+	if ta.post.URL != "" {
+		links = append([]commentLink{{url: ta.post.URL, author: "post"}}, links...)
+	}
+	if len(links) == 0 {
+		ta.setStatus("No links in this thread")
+		return
+	}
+
+	items := make([]pickerItem, len(links))
+	for i, link := range links {
+		items[i] = pickerItem{label: link.url, detail: link.author}
+	}
+	ta.showPicker(fmt.Sprintf("Links (%d)", len(links)), items, func(idx int) {
+		ta.openInBrowser(links[idx].url)
+	})
+}
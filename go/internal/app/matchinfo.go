@@ -0,0 +1,111 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+var (
+	// "Arsenal 2-1 Tottenham", "Arsenal [2-1](#bar) Tottenham"
+	scorePattern = regexp.MustCompile(`^(.*[A-Za-z].*?)\s+(\d{1,2})\s*[-–]\s*(\d{1,2})\s+(.*[A-Za-z].*)$`)
+	// "45'", "90+3'", "HT", "FT"
+	clockPattern   = regexp.MustCompile(`\b(\d{1,3}(?:\+\d{1,2})?)['’]`)
+	periodPattern  = regexp.MustCompile(`(?i)\b(HT|FT|half[- ]time|full[- ]time|kick[- ]off)\b`)
+	markdownLink   = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	markdownNoise  = strings.NewReplacer("**", "", "__", "", "#", "", "*", "", "|", " ")
+	maxTeamNameLen = 24
+)
+
+// matchInfo is the live score and clock shown in the match header.
+type matchInfo struct {
+	score string
+	clock string
+}
+
+func (m matchInfo) empty() bool {
+	return m.score == "" && m.clock == ""
+}
+
+// cleanMarkdown strips the link, bold and table markup match threads use
+// around scores, e.g. "**Arsenal [2-1](#bar-3) Spurs**".
+func cleanMarkdown(line string) string {
+	line = markdownLink.ReplaceAllString(line, "$1")
+	return strings.Join(strings.Fields(markdownNoise.Replace(line)), " ")
+}
+
+// trimTeam keeps team names short so the header fits on one line.
+func trimTeam(name string, fromEnd bool) string {
+	name = strings.TrimSpace(name)
+	runes := []rune(name)
+	if len(runes) <= maxTeamNameLen {
+		return name
+	}
+	if fromEnd {
+		return "…" + strings.TrimSpace(string(runes[len(runes)-maxTeamNameLen:]))
+	}
+	return strings.TrimSpace(string(runes[:maxTeamNameLen])) + "…"
+}
+
+// parseMatchInfo finds the first "Team 1-0 Team" line and the most recent
+// match clock ("67'", "HT") in text.
+func parseMatchInfo(text string) matchInfo {
+	var info matchInfo
+	for _, line := range strings.Split(text, "\n") {
+		line = cleanMarkdown(line)
+		if info.score == "" {
+			if m := scorePattern.FindStringSubmatch(line); m != nil {
+				info.score = fmt.Sprintf("%s %s-%s %s", trimTeam(m[1], true), m[2], m[3], trimTeam(m[4], false))
+			}
+		}
+		if m := periodPattern.FindAllString(line, -1); len(m) > 0 {
+			info.clock = strings.ToUpper(m[len(m)-1])
+		}
+		if m := clockPattern.FindAllStringSubmatch(line, -1); len(m) > 0 {
+			info.clock = m[len(m)-1][1] + "'"
+		}
+	}
+	return info
+}
+
+// currentMatchInfo prefers the stickied comment, which match-thread bots
+// keep updated, and falls back to the OP text for anything it lacks.
+func currentMatchInfo(post reddit.Post, comments []reddit.Comment) matchInfo {
+	var info matchInfo
+	for _, c := range comments {
+		if c.Stickied {
+			info = parseMatchInfo(c.Body)
+			break
+		}
+	}
+	op := parseMatchInfo(post.SelfText)
+	if info.score == "" {
+		info.score = op.score
+	}
+	if info.clock == "" {
+		info.clock = op.clock
+	}
+	return info
+}
+
+// updateMatchBar shows or hides the pinned score line for the comments
+// page. It is only shown with match_header enabled and something parsed.
+func (ta *TviewApp) updateMatchBar() {
+	pageName, _ := ta.pages.GetFrontPage()
+	info := currentMatchInfo(ta.post, ta.comments)
+	if !ta.cfg.MatchHeader || ta.splitMode || pageName != "comments" || info.empty() {
+		ta.mainFlex.ResizeItem(ta.matchBar, 0, 0)
+		return
+	}
+
+	ta.matchBar.Clear()
+	fmt.Fprintf(ta.matchBar, " [%s::b]%s[-:-:-]", ta.theme.Accent.Hex, tview.Escape(info.score))
+	if info.clock != "" {
+		fmt.Fprintf(ta.matchBar, "  [%s]%s[-]", ta.theme.Secondary.Hex, tview.Escape(info.clock))
+	}
+	ta.mainFlex.ResizeItem(ta.matchBar, 1, 0)
+}
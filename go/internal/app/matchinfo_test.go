@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestParseMatchInfo(t *testing.T) {
+	cases := []struct {
+		name  string
+		text  string
+		score string
+		clock string
+	}{
+		{"plain", "Arsenal 2-1 Tottenham\n\n67' Goal!", "Arsenal 2-1 Tottenham", "67'"},
+		{"markdown", "**Arsenal [2-1](#bar-3-white) Tottenham**", "Arsenal 2-1 Tottenham", ""},
+		{"stoppage", "Liverpool 0 - 0 Everton\n45+2' Booking\n90+3' Corner", "Liverpool 0-0 Everton", "90+3'"},
+		{"half time", "Real Madrid 1-1 Barcelona\nHT", "Real Madrid 1-1 Barcelona", "HT"},
+		{"date is not a score", "Kick-off 2024-05-01 20:00", "", "KICK-OFF"},
+		{"nothing", "Lineups below", "", ""},
+	}
+	for _, tc := range cases {
+		got := parseMatchInfo(tc.text)
+		if got.score != tc.score || got.clock != tc.clock {
+			t.Errorf("%s: parseMatchInfo = %+v, want score %q clock %q", tc.name, got, tc.score, tc.clock)
+		}
+	}
+}
+
+func TestCurrentMatchInfoPrefersStickied(t *testing.T) {
+	post := reddit.Post{SelfText: "Arsenal 0-0 Spurs\n1'"}
+	comments := []reddit.Comment{
+		{ID: "a", Body: "Arsenal 5-0 Spurs what a game"},
+		{ID: "b", Body: "Arsenal 1-0 Spurs\n23'", Stickied: true},
+	}
+	got := currentMatchInfo(post, comments)
+	if got.score != "Arsenal 1-0 Spurs" || got.clock != "23'" {
+		t.Errorf("currentMatchInfo = %+v, want stickied score and clock", got)
+	}
+}
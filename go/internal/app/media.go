@@ -0,0 +1,84 @@
+package app
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// mediaTag returns the styled " [media]" marker for threads that have an
+// image, gallery or video, or "" otherwise.
+func (ta *TviewApp) mediaTag(mediaURL string) string {
+	if mediaURL == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [%s][media[][-]", ta.theme.Accent.Hex)
+}
+
+// openMedia opens the current thread's image, gallery or video.
+func (ta *TviewApp) openMedia() {
+	url := ta.post.MediaURL
+	if url == "" && ta.currentThread != nil {
+		url = ta.currentThread.MediaURL
+	}
+	ta.openMediaURL(url)
+}
+
+// openSelectedThreadMedia opens the media of the highlighted thread in the
+// thread list without loading its comments.
+func (ta *TviewApp) openSelectedThreadMedia() {
+	if ta.threadIndex >= len(ta.threadsData) {
+		return
+	}
+	ta.openMediaURL(ta.threadsData[ta.threadIndex].MediaURL)
+}
+
+// openMediaURL opens url with the configured media_viewer, falling back to
+// the browser when none is set.
+func (ta *TviewApp) openMediaURL(url string) {
+	if url == "" {
+		ta.setStatus("This thread has no media")
+		return
+	}
+	if strings.TrimSpace(ta.cfg.MediaViewer) == "" {
+		ta.openInBrowser(url)
+		return
+	}
+	if err := runViewer(ta.cfg.MediaViewer, url); err != nil {
+		ta.setStatus(fmt.Sprintf("media_viewer failed: %v — %s", err, url))
+		return
+	}
+	ta.setStatus(fmt.Sprintf("Opened %s", url))
+}
+
+// viewerArgs splits a media_viewer command into program and arguments,
+// substituting url for a "{url}" placeholder or appending it otherwise.
+func viewerArgs(command, url string) []string {
+	args := strings.Fields(command)
+	placed := false
+	for i, arg := range args {
+		if strings.Contains(arg, "{url}") {
+			args[i] = strings.ReplaceAll(arg, "{url}", url)
+			placed = true
+		}
+	}
+	if !placed {
+		args = append(args, url)
+	}
+	return args
+}
+
+// runViewer starts the media viewer without waiting for it to exit.
+func runViewer(command, url string) error {
+	args := viewerArgs(command, url)
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(path, args[1:]...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestViewerArgs(t *testing.T) {
+	url := "https://v.redd.it/x"
+	cases := map[string][]string{
+		"mpv":                     {"mpv", url},
+		"feh --auto-zoom {url}":   {"feh", "--auto-zoom", url},
+		"vlc --url={url} --quiet": {"vlc", "--url=" + url, "--quiet"},
+	}
+	for cmd, want := range cases {
+		if got := viewerArgs(cmd, url); !reflect.DeepEqual(got, want) {
+			t.Errorf("viewerArgs(%q) = %q, want %q", cmd, got, want)
+		}
+	}
+}
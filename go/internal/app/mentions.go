@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// normalizeUsername strips the "u/" or "/u/" prefix users tend to paste
+// along with their name.
+func normalizeUsername(name string) string {
+	name = strings.TrimSpace(name)
+	name = strings.TrimPrefix(name, "/")
+	if len(name) > 2 && strings.EqualFold(name[:2], "u/") {
+		name = name[2:]
+	}
+	return name
+}
+
+// isUsernameRune reports whether r can be part of a Reddit username.
+func isUsernameRune(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// mentionsUser reports whether body contains a u/username mention,
+// case-insensitively and not as part of a longer name.
+func mentionsUser(body, username string) bool {
+	if username == "" {
+		return false
+	}
+	lower := strings.ToLower(body)
+	needle := "u/" + strings.ToLower(username)
+	for from := 0; ; {
+		i := strings.Index(lower[from:], needle)
+		if i < 0 {
+			return false
+		}
+		start, end := from+i, from+i+len(needle)
+		before, _ := utf8.DecodeLastRuneInString(lower[:start])
+		after, _ := utf8.DecodeRuneInString(lower[end:])
+		if (before == '/' || !isUsernameRune(before)) && !isUsernameRune(after) {
+			return true
+		}
+		from = end
+	}
+}
+
+// isOwnComment reports whether c was written by the configured username.
+func (ta *TviewApp) isOwnComment(c reddit.Comment) bool {
+	name := normalizeUsername(ta.cfg.Username)
+	return name != "" && strings.EqualFold(c.Author, name)
+}
+
+// isOP reports whether c was written by the submitter of the post being
+// shown. A deleted submitter matches nobody, since every deleted comment
+// shares that name.
+func (s *commentViewState) isOP(c reddit.Comment) bool {
+	op := s.post.Author
+	return op != "" && op != "[deleted]" && strings.EqualFold(c.Author, op)
+}
+
+// isMention reports whether c is addressed to the configured user: it
+// mentions u/username or replies directly to one of their comments.
+// authors maps comment IDs to their authors.
+func (ta *TviewApp) isMention(c reddit.Comment, authors map[string]string) bool {
+	name := normalizeUsername(ta.cfg.Username)
+	if name == "" || strings.EqualFold(c.Author, name) {
+		return false
+	}
+	if mentionsUser(c.Body, name) {
+		return true
+	}
+	parent, ok := authors[c.ParentID]
+	return ok && strings.EqualFold(parent, name)
+}
+
+// commentAuthors maps each comment ID to its author, for spotting replies.
+func commentAuthors(comments []reddit.Comment) map[string]string {
+	authors := make(map[string]string, len(comments))
+	for _, c := range comments {
+		authors[c.ID] = c.Author
+	}
+	return authors
+}
+
+// nextMention selects the next comment that mentions or replies to the
+// configured user, in display order, wrapping around and expanding
+// collapsed replies to reveal it.
+func (ta *TviewApp) nextMention() {
+	if normalizeUsername(ta.cfg.Username) == "" {
+		ta.setStatus("Set username in app_config.json to find mentions")
+		return
+	}
+	authors := commentAuthors(ta.comments)
+	visible := ta.visibleComments(ta.comments)
+	byID := make(map[string]reddit.Comment, len(visible))
+	for _, c := range visible {
+		byID[c.ID] = c
+	}
+	var ids []string
+	for _, id := range treeOrder(buildCommentTree(visible, filterQuery{}, nil)) {
+		if ta.isMention(byID[id], authors) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		ta.setStatus("No mentions or replies to you")
+		return
+	}
+
+	pos := 0
+	for i, id := range ids {
+		if id == ta.selectedID {
+			pos = (i + 1) % len(ids)
+			break
+		}
+	}
+
+	ta.expandAncestors(ids[pos], ta.comments)
+	ta.selectComment(ids[pos])
+	ta.setStatus(fmt.Sprintf("Mention %d/%d", pos+1, len(ids)))
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestMentionsUser(t *testing.T) {
+	cases := map[string]bool{
+		"thanks u/Fenneh":          true,
+		"cc /u/fenneh, any ideas?": true,
+		"(u/FENNEH)":               true,
+		"u/fenneh_alt posted it":   false,
+		"bu/fenneh":                false,
+		"fenneh said so":           false,
+	}
+	for body, want := range cases {
+		if got := mentionsUser(body, "fenneh"); got != want {
+			t.Errorf("mentionsUser(%q) = %v, want %v", body, got, want)
+		}
+	}
+}
+
+func TestIsMention(t *testing.T) {
+	ta := &TviewApp{cfg: config.AppConfig{Username: "u/fenneh"}}
+	comments := []reddit.Comment{
+		{ID: "a", Author: "fenneh", Body: "my take"},
+		{ID: "b", Author: "bob", Body: "disagree", ParentID: "a"},
+		{ID: "c", Author: "carol", Body: "agree with bob", ParentID: "b"},
+		{ID: "d", Author: "dave", Body: "ask u/fenneh"},
+	}
+	authors := commentAuthors(comments)
+	want := map[string]bool{"a": false, "b": true, "c": false, "d": true}
+	for _, c := range comments {
+		if got := ta.isMention(c, authors); got != want[c.ID] {
+			t.Errorf("isMention(%s) = %v, want %v", c.ID, got, want[c.ID])
+		}
+	}
+	if !ta.isOwnComment(comments[0]) || ta.isOwnComment(comments[1]) {
+		t.Error("isOwnComment should match only fenneh's comment")
+	}
+}
+
+func TestIsOP(t *testing.T) {
+	st := commentViewState{post: reddit.Post{Author: "MatchBot"}}
+	if !st.isOP(reddit.Comment{Author: "matchbot"}) {
+		t.Error("submitter's comment should be OP")
+	}
+	if st.isOP(reddit.Comment{Author: "someone"}) {
+		t.Error("other author marked OP")
+	}
+	st.post.Author = "[deleted]"
+	if st.isOP(reddit.Comment{Author: "[deleted]"}) {
+		t.Error("deleted submitter should match no comment")
+	}
+	if (&commentViewState{}).isOP(reddit.Comment{Author: ""}) {
+		t.Error("unknown submitter should match no comment")
+	}
+}
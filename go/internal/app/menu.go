@@ -0,0 +1,54 @@
+package app
+
+import (
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// noSelectableItems is shown when the menu config has nothing to pick.
+const noSelectableItems = "No selectable menu items — check your menu_config.json"
+
+func selectable(item config.MenuItem) bool {
+	return item.Type != "separator"
+}
+
+// firstMenuIndex returns the first selectable menu item, or -1 if the menu
+// is empty or holds only separators.
+func firstMenuIndex(items []config.MenuItem) int {
+	for i, item := range items {
+		if selectable(item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// stepMenuIndex moves from one selectable item to the next (delta > 0) or
+// previous one, wrapping around and skipping separators. It returns from
+// when no other item is selectable and -1 when none is.
+func stepMenuIndex(items []config.MenuItem, from, delta int) int {
+	n := len(items)
+	if firstMenuIndex(items) == -1 {
+		return -1
+	}
+	idx := from
+	for range n {
+		idx = ((idx+delta)%n + n) % n
+		if selectable(items[idx]) {
+			return idx
+		}
+	}
+	return from
+}
+
+// busiestThread returns the index of the thread with the most comments,
+// preferring the earliest on a tie.
+func busiestThread(threads []reddit.Thread) int {
+	best := 0
+	for i, t := range threads {
+		if t.NumComments > threads[best].NumComments {
+			best = i
+		}
+	}
+	return best
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestMenuIndexAllSeparators(t *testing.T) {
+	items := []config.MenuItem{{Type: "separator"}, {Type: "separator"}}
+	if got := firstMenuIndex(items); got != -1 {
+		t.Errorf("firstMenuIndex = %d, want -1", got)
+	}
+	if got := stepMenuIndex(items, -1, 1); got != -1 {
+		t.Errorf("stepMenuIndex down = %d, want -1", got)
+	}
+	if got := stepMenuIndex(items, -1, -1); got != -1 {
+		t.Errorf("stepMenuIndex up = %d, want -1", got)
+	}
+}
+
+func TestMenuIndexEmpty(t *testing.T) {
+	if got := firstMenuIndex(nil); got != -1 {
+		t.Errorf("firstMenuIndex = %d, want -1", got)
+	}
+	if got := stepMenuIndex(nil, 0, -1); got != -1 {
+		t.Errorf("stepMenuIndex = %d, want -1", got)
+	}
+}
+
+func TestStepMenuIndexSkipsSeparatorsAndWraps(t *testing.T) {
+	items := []config.MenuItem{
+		{Type: "separator"},
+		{Type: "soccer_match"},
+		{Type: "separator"},
+		{Type: "url_input"},
+	}
+	if got := firstMenuIndex(items); got != 1 {
+		t.Fatalf("firstMenuIndex = %d, want 1", got)
+	}
+	if got := stepMenuIndex(items, 1, 1); got != 3 {
+		t.Errorf("down from 1 = %d, want 3", got)
+	}
+	if got := stepMenuIndex(items, 3, 1); got != 1 {
+		t.Errorf("down from 3 = %d, want 1 (wrap)", got)
+	}
+	if got := stepMenuIndex(items, 1, -1); got != 3 {
+		t.Errorf("up from 1 = %d, want 3 (wrap)", got)
+	}
+}
+
+func TestStepMenuIndexSingleItem(t *testing.T) {
+	items := []config.MenuItem{{Type: "separator"}, {Type: "url_input"}}
+	if got := stepMenuIndex(items, 1, 1); got != 1 {
+		t.Errorf("stepMenuIndex = %d, want 1", got)
+	}
+}
+
+func TestBusiestThread(t *testing.T) {
+	threads := []reddit.Thread{{NumComments: 10}, {NumComments: 250}, {NumComments: 250}, {NumComments: 3}}
+	if got := busiestThread(threads); got != 1 {
+		t.Errorf("busiestThread = %d, want 1", got)
+	}
+}
+
+func TestKeepMenuSelection(t *testing.T) {
+	old := []config.MenuItem{{Title: "A"}, {Type: "separator"}, {Title: "B"}}
+	items := []config.MenuItem{{Title: "New"}, {Title: "B"}, {Title: "A"}}
+	if got := keepMenuSelection(old, items, 2); got != 1 {
+		t.Errorf("B moved to %d, want 1", got)
+	}
+	if got := keepMenuSelection(old, items[:1], 2); got != 0 {
+		t.Errorf("removed item should fall back to the first, got %d", got)
+	}
+	if got := keepMenuSelection(old, []config.MenuItem{{Type: "separator"}}, 0); got != -1 {
+		t.Errorf("nothing selectable should give -1, got %d", got)
+	}
+}
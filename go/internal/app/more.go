@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// mergeMore adds comments fetched through "load more" stubs to a fresh
+// load of the thread, which Reddit still answers with the stubs, and drops
+// the IDs they cover from the stubs. Comments the load already contains
+// are not duplicated.
+func mergeMore(comments []reddit.Comment, post reddit.Post, loaded []reddit.Comment) ([]reddit.Comment, reddit.Post) {
+	if len(loaded) == 0 {
+		return comments, post
+	}
+	have := make(map[string]bool, len(comments)+len(loaded))
+	for _, c := range comments {
+		have[c.ID] = true
+	}
+	for _, c := range loaded {
+		if !have[c.ID] {
+			have[c.ID] = true
+			comments = append(comments, c)
+		}
+	}
+	for i := range comments {
+		comments[i].MoreChildren = pending(comments[i].MoreChildren, have)
+	}
+	post.MoreChildren = pending(post.MoreChildren, have)
+	return comments, post
+}
+
+// pending returns the ids that are not in have.
+func pending(ids []string, have map[string]bool) []string {
+	var out []string
+	for _, id := range ids {
+		if !have[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// loadMore fetches the replies hidden behind the "load more" stub of
+// comment parentID, or the thread's top-level stub when parentID is "".
+// The fetched comments are kept across refreshes.
+func (ta *TviewApp) loadMore(parentID string) {
+	ids := ta.post.MoreChildren
+	if parentID != "" {
+		for _, c := range ta.comments {
+			if c.ID == parentID {
+				ids = c.MoreChildren
+				break
+			}
+		}
+	}
+	if len(ids) == 0 || ta.currentThread == nil || ta.loadingMore {
+		return
+	}
+	ta.loadingMore = true
+	ta.setStatus(fmt.Sprintf("Loading %d more...", len(ids)))
+
+	thread := ta.currentThread
+	ctx := ta.requests.context()
+	go func() {
+		more, err := ta.client.FetchMoreCommentsContext(ctx, thread.ID, ids)
+		ta.app.QueueUpdateDraw(func() {
+			if thread != ta.currentThread || errors.Is(err, context.Canceled) {
+				return
+			}
+			ta.loadingMore = false
+			if err != nil {
+				ta.loadFailed("load more comments", err, func() { ta.loadMore(parentID) })
+				return
+			}
+			ta.loadSucceeded()
+			// Older comments revealed by the stub are not news
+			for _, c := range more {
+				if ta.seen != nil {
+					ta.seen[c.ID] = true
+				}
+				if ta.opened != nil {
+					ta.opened[c.ID] = true
+				}
+			}
+			ta.moreLoaded = append(ta.moreLoaded, more...)
+			ta.comments, ta.post = mergeMore(ta.comments, ta.post, ta.moreLoaded)
+			if ta.commentSort.Chronological() {
+				sort.SliceStable(ta.comments, func(i, j int) bool {
+					return ta.comments[i].CreatedUTC < ta.comments[j].CreatedUTC
+				})
+			}
+			ta.renderComments()
+			if parentID != "" {
+				ta.scrollToSelected()
+			}
+			ta.setStatus(fmt.Sprintf("Loaded %d more comments", len(more)))
+		})
+	}()
+}
+
+// loadMoreSelected fetches the "load more" stub of the selected comment, or
+// the thread's top-level stub when the selection has none.
+func (ta *TviewApp) loadMoreSelected() {
+	if idx, ok := ta.selectedComment(); ok && len(ta.comments[idx].MoreChildren) > 0 {
+		ta.loadMore(ta.comments[idx].ID)
+		return
+	}
+	ta.loadMore("")
+}
+
+// cancelRequests aborts the current thread's requests without resetting
+// its view state. A "load more" in flight is dropped with them, so it no
+// longer blocks the next one.
+func (ta *TviewApp) cancelRequests() {
+	ta.requests.cancelAll()
+	ta.loadingMore = false
+}
+
+// plural returns one when n is 1 and many otherwise.
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestMergeMore(t *testing.T) {
+	fresh := []reddit.Comment{
+		{ID: "a", MoreChildren: []string{"b", "c"}},
+		{ID: "c", ParentID: "a"},
+	}
+	post := reddit.Post{MoreChildren: []string{"d", "e"}}
+	loaded := []reddit.Comment{
+		{ID: "b", ParentID: "a"},
+		{ID: "c", ParentID: "a"}, // arrived in the refresh as well
+		{ID: "d"},
+	}
+
+	comments, post := mergeMore(fresh, post, loaded)
+	if len(comments) != 4 {
+		t.Fatalf("got %d comments, want 4: %+v", len(comments), comments)
+	}
+	if comments[2].ID != "b" || comments[3].ID != "d" {
+		t.Errorf("loaded comments should be appended once, got %+v", comments)
+	}
+	if len(comments[0].MoreChildren) != 0 {
+		t.Errorf("stub still lists %v after its replies were loaded", comments[0].MoreChildren)
+	}
+	if len(post.MoreChildren) != 1 || post.MoreChildren[0] != "e" {
+		t.Errorf("post stub = %v, want [e]", post.MoreChildren)
+	}
+
+	roots := buildCommentTree(comments, filterQuery{}, nil)
+	if len(roots) != 2 || len(roots[0].children) != 2 {
+		t.Errorf("loaded replies should slot under their parent, got %d roots", len(roots))
+	}
+}
+
+func TestCancelRequestsReleasesLoadMore(t *testing.T) {
+	ta := &TviewApp{}
+	ctx := ta.requests.context()
+	ta.loadingMore = true
+	ta.cancelRequests()
+	if ctx.Err() == nil {
+		t.Error("the in-flight request should be cancelled")
+	}
+	if ta.loadingMore {
+		t.Error("a cancelled load more should not block the next one")
+	}
+}
+
+func TestHasReplies(t *testing.T) {
+	comments := []reddit.Comment{
+		{ID: "a", MoreChildren: []string{"d"}},
+		{ID: "b", ParentID: "a"},
+		{ID: "c", MoreChildren: []string{"e"}},
+	}
+	if !hasReplies(comments, "a") {
+		t.Error("a has a loaded reply, so Enter should collapse it")
+	}
+	if hasReplies(comments, "c") {
+		t.Error("c has only a stub, so Enter should load it")
+	}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Region ID prefixes for clickable menu items and threads.
+const (
+	menuRegion   = "menu-"
+	threadRegion = "thread-"
+)
+
+// clickRegion returns the region ID wrapping entry idx of a list.
+func clickRegion(prefix string, idx int) string {
+	return prefix + strconv.Itoa(idx)
+}
+
+// clickedIndex parses a region ID built by clickRegion with prefix.
+func clickedIndex(region, prefix string) (int, bool) {
+	rest, ok := strings.CutPrefix(region, prefix)
+	if !ok {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(rest)
+	return idx, err == nil
+}
+
+// enableClicks opens a menu item or thread when it is clicked. tview
+// reports a click on a region as a highlight; it is cleared again right
+// away so the list keeps its own selection styling. Clicks only arrive
+// with the mouse option on.
+func (ta *TviewApp) enableClicks() {
+	ta.menuView.SetRegions(true).SetHighlightedFunc(func(added, _, _ []string) {
+		if len(added) == 0 {
+			return
+		}
+		ta.menuView.Highlight()
+		if idx, ok := clickedIndex(added[0], menuRegion); ok && idx < len(ta.menuItems) && selectable(ta.menuItems[idx]) {
+			ta.menuIndex = idx
+			ta.selectMenuItem(idx, false)
+		}
+	})
+	ta.threadView.SetRegions(true).SetHighlightedFunc(func(added, _, _ []string) {
+		if len(added) == 0 {
+			return
+		}
+		ta.threadView.Highlight()
+		if idx, ok := clickedIndex(added[0], threadRegion); ok && idx < len(ta.threadsData) {
+			ta.threadIndex = idx
+			ta.selectThread(idx)
+		}
+	})
+}
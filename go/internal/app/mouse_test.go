@@ -0,0 +1,15 @@
+package app
+
+import "testing"
+
+func TestClickedIndex(t *testing.T) {
+	if idx, ok := clickedIndex(clickRegion(threadRegion, 12), threadRegion); !ok || idx != 12 {
+		t.Errorf("clickedIndex = %d, %v; want 12, true", idx, ok)
+	}
+	if _, ok := clickedIndex(clickRegion(menuRegion, 3), threadRegion); ok {
+		t.Error("a menu region should not parse as a thread")
+	}
+	if _, ok := clickedIndex("thread-x", threadRegion); ok {
+		t.Error("a malformed region should not parse")
+	}
+}
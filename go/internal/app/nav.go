@@ -0,0 +1,39 @@
+package app
+
+// pushNav records page as the current view. Returning to a page already on
+// the stack drops everything above it, so the stack never loops.
+func (ta *TviewApp) pushNav(page string) {
+	for i, p := range ta.navStack {
+		if p == page {
+			ta.navStack = ta.navStack[:i+1]
+			return
+		}
+	}
+	ta.navStack = append(ta.navStack, page)
+}
+
+// navBack returns to the view shown before the current one, e.g. comments
+// opened by URL go back to the URL input rather than a stale thread list.
+// It does nothing at the menu.
+func (ta *TviewApp) navBack() {
+	if len(ta.navStack) < 2 {
+		return
+	}
+	if ta.navStack[len(ta.navStack)-1] == "comments" {
+		ta.stopAutoRefresh()
+		ta.cancelRequests()
+	}
+	prev := ta.navStack[len(ta.navStack)-2]
+	ta.navStack = ta.navStack[:len(ta.navStack)-2] // re-pushed by the show call
+
+	switch prev {
+	case "threads":
+		ta.showThreads()
+	case "url":
+		ta.showURLInput()
+	case "comments":
+		ta.showComments()
+	default:
+		ta.showMenu()
+	}
+}
@@ -0,0 +1,21 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPushNav(t *testing.T) {
+	ta := &TviewApp{}
+	for _, page := range []string{"menu", "url", "threads", "comments", "comments"} {
+		ta.pushNav(page)
+	}
+	if want := []string{"menu", "url", "threads", "comments"}; !reflect.DeepEqual(ta.navStack, want) {
+		t.Errorf("navStack = %v, want %v", ta.navStack, want)
+	}
+
+	ta.pushNav("menu")
+	if want := []string{"menu"}; !reflect.DeepEqual(ta.navStack, want) {
+		t.Errorf("returning to the menu should reset the stack, got %v", ta.navStack)
+	}
+}
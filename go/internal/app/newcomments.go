@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// trackArrivals records every comment of the first load as seen, so only
+// comments that arrive in later refreshes are marked new. Later arrivals
+// are timestamped with now for timed retention and returned. The first
+// load is also kept as the thread's open-time snapshot for the catch-up
+// view.
+func (s *commentViewState) trackArrivals(comments []reddit.Comment, now time.Time) []reddit.Comment {
+	if s.seen == nil {
+		s.seen = make(map[string]bool, len(comments))
+		s.opened = make(map[string]bool, len(comments))
+		for _, c := range comments {
+			s.seen[c.ID] = true
+			s.opened[c.ID] = true
+		}
+		return nil
+	}
+	if s.arrived == nil {
+		s.arrived = make(map[string]time.Time)
+	}
+	var arrivals []reddit.Comment
+	for _, c := range comments {
+		if _, ok := s.arrived[c.ID]; !ok && !s.seen[c.ID] {
+			s.arrived[c.ID] = now
+			arrivals = append(arrivals, c)
+		}
+	}
+	return arrivals
+}
+
+// expireNew retires "new" markers according to the retention mode from
+// config.AppConfig.NewHighlightRetention. It runs before each refresh is
+// merged: "refresh" retires everything marked so far, "scroll" retires
+// comments visible on screen, and "timed" retires comments older than ttl.
+// visible may be nil outside "scroll" mode.
+func (s *commentViewState) expireNew(mode string, ttl time.Duration, visible func(id string) bool, now time.Time) {
+	for id, at := range s.arrived {
+		expired := false
+		switch mode {
+		case config.HighlightRefresh:
+			expired = true
+		case config.HighlightScroll:
+			expired = visible != nil && visible(id)
+		case config.HighlightTimed:
+			expired = now.Sub(at) >= ttl
+		}
+		if expired {
+			s.seen[id] = true
+			delete(s.arrived, id)
+		}
+	}
+}
+
+// visibleIn reports whether id was drawn within the rows currently shown
+// by a view scrolled to row with the given height.
+func (s *commentViewState) visibleIn(row, height int) func(id string) bool {
+	return func(id string) bool {
+		line, ok := s.lineOf(id)
+		return ok && line >= row && line < row+height
+	}
+}
+
+// isNew reports whether id arrived after the first load and has not been
+// marked as seen yet.
+func (s *commentViewState) isNew(id string) bool {
+	return s.seen != nil && !s.seen[id]
+}
+
+// markAllSeen clears every "new" marker and returns how many were cleared.
+func (s *commentViewState) markAllSeen(comments []reddit.Comment) int {
+	if s.seen == nil {
+		s.seen = make(map[string]bool, len(comments))
+	}
+	cleared := 0
+	for _, c := range comments {
+		if !s.seen[c.ID] {
+			s.seen[c.ID] = true
+			cleared++
+		}
+	}
+	s.arrived = nil
+	return cleared
+}
+
+// clearNewMarkers acknowledges all new comments without moving the view.
+func (ta *TviewApp) clearNewMarkers() {
+	cleared := ta.markAllSeen(ta.comments)
+	ta.renderComments()
+	ta.setStatus(fmt.Sprintf("Marked %d new comments as read", cleared))
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestTrackArrivalsFirstLoadIsNotNew(t *testing.T) {
+	var st commentViewState
+	if st.isNew("a") {
+		t.Fatal("nothing should be new before the first load")
+	}
+	st.trackArrivals([]reddit.Comment{{ID: "a"}, {ID: "b"}}, time.Now())
+	for _, id := range []string{"a", "b"} {
+		if st.isNew(id) {
+			t.Errorf("%s from the first load marked new", id)
+		}
+	}
+}
+
+func TestTrackArrivalsReturnsNewComments(t *testing.T) {
+	var st commentViewState
+	first := []reddit.Comment{{ID: "a"}}
+	if got := st.trackArrivals(first, time.Time{}); got != nil {
+		t.Errorf("first load returned %d arrivals, want none", len(got))
+	}
+	got := st.trackArrivals(append(first, reddit.Comment{ID: "b"}), time.Time{})
+	if len(got) != 1 || got[0].ID != "b" {
+		t.Errorf("arrivals = %v, want [b]", got)
+	}
+}
+
+func TestRefreshRetentionLastsOneCycle(t *testing.T) {
+	var st commentViewState
+	now := time.Now()
+	first := []reddit.Comment{{ID: "a"}}
+	st.trackArrivals(first, now)
+
+	second := append(first, reddit.Comment{ID: "b"})
+	st.expireNew(config.HighlightRefresh, 0, nil, now)
+	st.trackArrivals(second, now)
+	if !st.isNew("b") {
+		t.Fatal("comment arriving on refresh should be new")
+	}
+
+	third := append(second, reddit.Comment{ID: "c"})
+	st.expireNew(config.HighlightRefresh, 0, nil, now)
+	st.trackArrivals(third, now)
+	if st.isNew("b") {
+		t.Error("b should lose its marker on the following refresh")
+	}
+	if !st.isNew("c") {
+		t.Error("c should be new after the refresh it arrived in")
+	}
+}
+
+func TestTimedRetentionExpires(t *testing.T) {
+	var st commentViewState
+	now := time.Now()
+	st.trackArrivals([]reddit.Comment{{ID: "a"}}, now)
+	st.trackArrivals([]reddit.Comment{{ID: "a"}, {ID: "b"}}, now)
+
+	st.expireNew(config.HighlightTimed, time.Minute, nil, now.Add(30*time.Second))
+	if !st.isNew("b") {
+		t.Error("b expired before its ttl")
+	}
+	st.expireNew(config.HighlightTimed, time.Minute, nil, now.Add(time.Minute))
+	if st.isNew("b") {
+		t.Error("b still new after its ttl")
+	}
+}
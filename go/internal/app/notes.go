@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// noteSuffix returns the styled " — note" suffix for a thread, or empty
+// string if the thread has no note.
+func (ta *TviewApp) noteSuffix(threadID string) string {
+	note := ta.notes[threadID]
+	if note == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]— %s[-]", ta.theme.Muted.Hex, note)
+}
+
+// threadTitle returns the header title for the current thread, including
+// its media marker and note if set.
+func (ta *TviewApp) threadTitle() string {
+	if ta.currentThread == nil {
+		return "Comments"
+	}
+	media := ta.post.MediaURL
+	if media == "" {
+		media = ta.currentThread.MediaURL
+	}
+	title := ta.currentThread.Title
+	if ta.compactHeader {
+		title = ta.threadSummary(time.Now())
+	}
+	title += ta.mediaTag(media)
+	if ta.commentSort != "" {
+		title += fmt.Sprintf(" [%s](%s)[-]", ta.theme.Muted.Hex, ta.commentSort.Label())
+	}
+	if ta.sinceOpen {
+		title += fmt.Sprintf(" [%s](since opened)[-]", ta.theme.Muted.Hex)
+	}
+	if q := parseFilterQuery(ta.commentFilter); q.hasMinScore {
+		title += fmt.Sprintf(" [%s](score ≥ %d)[-]", ta.theme.Muted.Hex, q.minScore)
+	}
+	if ta.refreshPaused {
+		title += fmt.Sprintf(" [%s](paused)[-]", ta.theme.Muted.Hex)
+	}
+	return title + ta.noteSuffix(ta.currentThread.ID)
+}
+
+// editNote prompts for a note on thread and saves it, refreshing whichever
+// view shows the note.
+func (ta *TviewApp) editNote(thread *reddit.Thread) {
+	if thread == nil {
+		return
+	}
+	ta.prompt("Note: ", ta.notes[thread.ID], func(text string) {
+		path, err := config.SaveNote(thread.ID, text)
+		if err != nil {
+			ta.setStatus(fmt.Sprintf("Note not saved: %v", err))
+			return
+		}
+		ta.notes = config.LoadNotes()
+
+		pageName, _ := ta.pages.GetFrontPage()
+		switch pageName {
+		case "threads":
+			ta.showThreads()
+		case "comments":
+			ta.showComments()
+		}
+		ta.setStatus(fmt.Sprintf("Note saved to %s", path))
+	})
+}
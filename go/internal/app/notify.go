@@ -0,0 +1,121 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+var errNoNotifier = errors.New("no desktop notifier available")
+
+// notifyBodyLen caps the comment text quoted in a notification.
+const notifyBodyLen = 120
+
+// windowsToast shows a balloon from the tray; the title and body come in
+// through the environment so nothing needs quoting.
+const windowsToast = `Add-Type -AssemblyName System.Windows.Forms
+$n = New-Object System.Windows.Forms.NotifyIcon
+$n.Icon = [System.Drawing.SystemIcons]::Information
+$n.Visible = $true
+$n.ShowBalloonTip(5000, $env:RSC_NOTIFY_TITLE, $env:RSC_NOTIFY_BODY, 'Info')
+Start-Sleep -Seconds 6
+$n.Dispose()`
+
+// sendNotification shows a desktop notification with the platform's own
+// tool: osascript on macOS, PowerShell on Windows and notify-send
+// elsewhere. Like openURL it returns errNoNotifier on a headless box.
+func sendNotification(title, body string) error {
+	var name string
+	var args []string
+	switch runtime.GOOS {
+	case "darwin":
+		name, args = "osascript", []string{"-e",
+			`display notification (system attribute "RSC_NOTIFY_BODY") with title (system attribute "RSC_NOTIFY_TITLE")`}
+	case "windows":
+		name, args = "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", windowsToast}
+	default:
+		if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			return errNoNotifier
+		}
+		name, args = "notify-send", []string{"--app-name=reddit-stream-console", "--", title, body}
+	}
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return errNoNotifier
+	}
+	cmd := exec.Command(path, args...)
+	cmd.Env = append(os.Environ(), "RSC_NOTIFY_TITLE="+title, "RSC_NOTIFY_BODY="+body)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// keywordNotification builds the text of the notification for comments
+// that arrived in one refresh: the first comment matching re, plus a count
+// of the other matches, so a flurry of goal comments raises one
+// notification. ok is false when nothing matched.
+func keywordNotification(arrivals []reddit.Comment, re *regexp.Regexp) (body string, ok bool) {
+	var first *reddit.Comment
+	matches := 0
+	for i := range arrivals {
+		if !containsKeyword(arrivals[i].Body, re) {
+			continue
+		}
+		if first == nil {
+			first = &arrivals[i]
+		}
+		matches++
+	}
+	if first == nil {
+		return "", false
+	}
+	text := strings.Join(strings.Fields(first.Body), " ")
+	if runes := []rune(text); len(runes) > notifyBodyLen {
+		text = strings.TrimSpace(string(runes[:notifyBodyLen-1])) + "…"
+	}
+	body = fmt.Sprintf("%s: %s", first.Author, text)
+	if matches > 1 {
+		body += fmt.Sprintf(" (+%d more)", matches-1)
+	}
+	return body, true
+}
+
+// containsKeyword reports whether text contains a whole-word match of re.
+func containsKeyword(text string, re *regexp.Regexp) bool {
+	if re == nil {
+		return false
+	}
+	for _, m := range re.FindAllStringIndex(text, -1) {
+		if wordEdge(text, m[0], m[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyKeywords raises at most one desktop notification for the comments
+// that arrived in a refresh of thread, when enable_notifications is on and
+// any of them mention a highlight keyword. After the first failure, e.g.
+// over SSH, it is reported once and notifications stay off for the session.
+func (ta *TviewApp) notifyKeywords(thread *reddit.Thread, arrivals []reddit.Comment, re *regexp.Regexp) {
+	if !ta.cfg.EnableNotifications || ta.notifyFailed || thread == nil || len(arrivals) == 0 {
+		return
+	}
+	body, ok := keywordNotification(arrivals, re)
+	if !ok {
+		return
+	}
+	if err := sendNotification(thread.Title, body); err != nil {
+		ta.notifyFailed = true
+		ta.setStatus(fmt.Sprintf("Notification failed: %v — notifications off until restart", err))
+	}
+}
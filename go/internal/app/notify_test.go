@@ -0,0 +1,31 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestKeywordNotification(t *testing.T) {
+	re := compileKeywords([]string{"goal"})
+	arrivals := []reddit.Comment{
+		{Author: "alice", Body: "what a save"},
+		{Author: "bob", Body: "GOAL!!\n\nSaka 1-0"},
+		{Author: "carol", Body: "goal goal goal"},
+		{Author: "dave", Body: "the goalkeeper was rooted"},
+	}
+	body, ok := keywordNotification(arrivals, re)
+	if !ok {
+		t.Fatal("expected a notification")
+	}
+	if want := "bob: GOAL!! Saka 1-0 (+1 more)"; body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+
+	if _, ok := keywordNotification(arrivals[:1], re); ok {
+		t.Error("no matching comment should mean no notification")
+	}
+	if _, ok := keywordNotification(arrivals, nil); ok {
+		t.Error("no keywords should mean no notification")
+	}
+}
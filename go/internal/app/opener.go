@@ -0,0 +1,65 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+var errNoOpener = errors.New("no browser opener available")
+
+// openURL opens url with the platform's default handler. Over SSH or on a
+// headless Linux box there is nothing to open it with, so errNoOpener is
+// returned and callers fall back to showing the URL.
+func openURL(url string) error {
+	var name string
+	var args []string
+	switch runtime.GOOS {
+	case "darwin":
+		name, args = "open", []string{url}
+	case "windows":
+		// rundll32 avoids cmd.exe interpreting & and ^ in the URL
+		name, args = "rundll32", []string{"url.dll,FileProtocolHandler", url}
+	default:
+		if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			return errNoOpener
+		}
+		name, args = "xdg-open", []string{url}
+	}
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return errNoOpener
+	}
+	cmd := exec.Command(path, args...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// openInBrowser opens url and reports the outcome in the status bar,
+// printing the URL instead when no opener is available.
+func (ta *TviewApp) openInBrowser(url string) {
+	if err := openURL(url); err != nil {
+		ta.setStatus(fmt.Sprintf("Can't open a browser here — %s", url))
+		return
+	}
+	ta.setStatus(fmt.Sprintf("Opened %s", url))
+}
+
+// openSelectedInBrowser opens the selected comment's permalink, or the
+// thread's when no comment is selected.
+func (ta *TviewApp) openSelectedInBrowser() {
+	if ta.currentThread == nil {
+		return
+	}
+	if idx, ok := ta.selectedComment(); ok {
+		ta.openInBrowser(commentPermalink(ta.currentThread.Permalink, ta.comments[idx].ID))
+		return
+	}
+	ta.openInBrowser("https://reddit.com" + ta.currentThread.Permalink)
+}
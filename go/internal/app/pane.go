@@ -5,8 +5,8 @@ import (
 	"github.com/rivo/tview"
 
 	"github.com/fenneh/reddit-stream-console/internal/config"
-	"github.com/fenneh/reddit-stream-console/internal/reddit"
 	"github.com/fenneh/reddit-stream-console/internal/theme"
+	"github.com/fenneh/reddit-stream-console/reddit"
 )
 
 type CommentPane struct {
@@ -18,7 +18,10 @@ type CommentPane struct {
 	commentFilter  string
 	filterActive   bool
 	refreshEnabled bool
+	refreshPaused  bool // ticks skip the fetch until toggled back
 	stopRefresh    chan struct{}
+	requests       requestScope // requests for thread, cancelled when it changes
+	commentViewState
 
 	theme theme.Theme
 
@@ -28,6 +31,12 @@ type CommentPane struct {
 	threadIndex    int
 	threadsData    []reddit.Thread
 	currentMenu    *config.MenuItem
+
+	// detail, when set, is the pane that shows the comments of this
+	// pane's selected thread (master-detail split).
+	detail *CommentPane
+	// sort is the server-side comment order for this pane; empty = new.
+	sort reddit.CommentSort
 }
 
 func NewCommentPane(id string, t theme.Theme) *CommentPane {
@@ -57,6 +66,7 @@ func NewCommentPane(id string, t theme.Theme) *CommentPane {
 }
 
 func (p *CommentPane) Clear() {
+	p.requests.cancelAll()
 	p.thread = nil
 	p.comments = nil
 	p.commentFilter = ""
@@ -67,6 +77,9 @@ func (p *CommentPane) Clear() {
 	p.threadIndex = 0
 	p.threadsData = nil
 	p.currentMenu = nil
+	p.detail = nil
+	p.sort = ""
+	p.commentViewState = commentViewState{}
 	p.view.Clear()
 }
 
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// pasteTimeout bounds a recap upload so a dead paste service can't hang
+// the share action.
+const pasteTimeout = 15 * time.Second
+
+// uploadPaste POSTs text to a paste endpoint and returns the URL of the new
+// paste. It expects services in the style of paste.rs, which take the raw
+// body and answer with the paste URL as plain text. The request goes
+// through client, e.g. to honour proxy_url.
+func uploadPaste(ctx context.Context, client *http.Client, endpoint, text string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(text))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("paste upload: http %d", resp.StatusCode)
+	}
+	url := strings.TrimSpace(string(body))
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return "", fmt.Errorf("paste upload: unexpected response %q", url)
+	}
+	return url, nil
+}
+
+// shareRecap uploads a recap of the current thread to the configured paste
+// endpoint and copies the resulting link to the clipboard.
+func (ta *TviewApp) shareRecap() {
+	if ta.cfg.PasteEndpoint == "" {
+		ta.setStatus("Sharing needs paste_endpoint set in app_config.json")
+		return
+	}
+	if ta.currentThread == nil {
+		return
+	}
+	text := recapMarkdown(*ta.currentThread, ta.post, ta.comments)
+	endpoint := ta.cfg.PasteEndpoint
+	ta.setStatus("Uploading recap...")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), pasteTimeout)
+		defer cancel()
+		url, err := uploadPaste(ctx, &http.Client{Transport: ta.client.Transport()}, endpoint, text)
+		ta.app.QueueUpdateDraw(func() {
+			if err != nil {
+				ta.setStatus(fmt.Sprintf("Recap upload failed: %v", err))
+				return
+			}
+			if err := ta.copyToClipboard(url); err != nil {
+				ta.setStatus(fmt.Sprintf("Recap shared — %s", url))
+				return
+			}
+			ta.setStatus(fmt.Sprintf("Recap shared, link copied: %s", url))
+		})
+	}()
+}
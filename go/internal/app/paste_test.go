@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestUploadPaste(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got = string(b)
+		io.WriteString(w, "https://paste.example/abc\n")
+	}))
+	defer srv.Close()
+
+	url, err := uploadPaste(context.Background(), srv.Client(), srv.URL, "# recap")
+	if err != nil {
+		t.Fatalf("uploadPaste: %v", err)
+	}
+	if url != "https://paste.example/abc" || got != "# recap" {
+		t.Errorf("url = %q, body = %q", url, got)
+	}
+}
+
+func TestUploadPasteRejectsNonURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "<html>error</html>")
+	}))
+	defer srv.Close()
+
+	if _, err := uploadPaste(context.Background(), srv.Client(), srv.URL, "x"); err == nil {
+		t.Error("expected an error for a non-URL response")
+	}
+}
+
+func TestRecapMarkdownTopComments(t *testing.T) {
+	comments := []reddit.Comment{
+		{ID: "a", Author: "low", Body: "meh", Score: 1},
+		{ID: "b", Author: "high", Body: "great\ngoal", Score: 50, FormattedTime: "12:00"},
+		{ID: "c", Author: "reply", Body: "agreed", Score: 99, ParentID: "b"},
+	}
+	md := recapMarkdown(reddit.Thread{Title: "Match Thread", Permalink: "/r/soccer/comments/x/"}, reddit.Post{}, comments)
+
+	if !strings.Contains(md, "https://reddit.com/r/soccer/comments/x/") {
+		t.Errorf("recap missing link:\n%s", md)
+	}
+	if strings.Contains(md, "agreed") {
+		t.Error("recap should only include top-level comments")
+	}
+	if hi, lo := strings.Index(md, "**high**"), strings.Index(md, "**low**"); hi == -1 || lo == -1 || hi > lo {
+		t.Errorf("expected comments ordered by score:\n%s", md)
+	}
+	if !strings.Contains(md, "- **high** (50 points, 12:00): great\n  goal\n") {
+		t.Errorf("expected the export's bullet format:\n%s", md)
+	}
+}
@@ -0,0 +1,39 @@
+package app
+
+import "fmt"
+
+// toggleRefreshPause pauses or resumes auto-refresh for the comments view,
+// or for the active pane in split mode. While paused the refresh ticker
+// keeps running but skips its fetch; resuming refreshes straight away.
+func (ta *TviewApp) toggleRefreshPause() {
+	if ta.splitMode {
+		pane := ta.getActivePane()
+		if pane == nil || pane.thread == nil {
+			return
+		}
+		pane.refreshPaused = !pane.refreshPaused
+		if !pane.refreshPaused {
+			ta.loadCommentsForPane(pane)
+		}
+		pane.view.SetTitle(ta.paneCountTitle(pane))
+		ta.setStatus(fmt.Sprintf("Pane %s: %s", pane.id, liveLabel(pane.refreshPaused)))
+		return
+	}
+	if ta.currentThread == nil {
+		return
+	}
+	ta.refreshPaused = !ta.refreshPaused
+	if !ta.refreshPaused {
+		ta.loadComments()
+	}
+	ta.updateHeader(ta.threadTitle(), commentsKeys)
+	ta.setStatus(liveLabel(ta.refreshPaused))
+}
+
+// liveLabel describes the auto-refresh state for the status bar.
+func liveLabel(paused bool) string {
+	if paused {
+		return "Refresh paused — press f to go live"
+	}
+	return "Live — auto-refresh resumed"
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// pickerItem is one selectable row in a picker.
+type pickerItem struct {
+	label  string
+	detail string
+}
+
+// showPicker overlays a bordered list on the current page. onSelect is
+// called with the chosen index after the picker closes; Esc closes it
+// without a selection.
+func (ta *TviewApp) showPicker(title string, items []pickerItem, onSelect func(idx int)) {
+	list := tview.NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true).
+		SetMainTextColor(ta.theme.Secondary.TCell).
+		SetSelectedTextColor(ta.theme.Accent.TCell).
+		SetSelectedBackgroundColor(ta.theme.InputBg.TCell)
+	list.SetBorder(true)
+	list.SetBorderColor(ta.theme.Border.TCell)
+	list.SetTitle(" " + title + " ")
+	list.SetTitleColor(ta.theme.Primary.TCell)
+
+	width := len(title) + 6
+	for _, item := range items {
+		label := item.label
+		if item.detail != "" {
+			label += "  [" + ta.theme.Muted.Hex + "]" + item.detail + "[-]"
+		}
+		list.AddItem(label, "", 0, nil)
+		if w := tview.TaggedStringWidth(label) + 6; w > width {
+			width = w
+		}
+	}
+
+	list.SetSelectedFunc(func(idx int, _, _ string, _ rune) {
+		ta.closePicker()
+		onSelect(idx)
+	})
+	list.SetDoneFunc(ta.closePicker)
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'j':
+			return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+		case 'k':
+			return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+		}
+		return event
+	})
+
+	_, _, termWidth, termHeight := ta.mainFlex.GetInnerRect()
+	if termWidth > 0 && width > termWidth-4 {
+		width = termWidth - 4
+	}
+	height := len(items) + 2
+	if termHeight > 0 && height > termHeight-4 {
+		height = termHeight - 4
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(list, height, 0, true).
+			AddItem(nil, 0, 1, false), width, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	ta.pickerReturnFocus = ta.app.GetFocus()
+	ta.pages.AddPage("picker", modal, true, true)
+	ta.app.SetFocus(list)
+}
+
+func (ta *TviewApp) closePicker() {
+	ta.pages.RemovePage("picker")
+	if ta.pickerReturnFocus != nil {
+		ta.app.SetFocus(ta.pickerReturnFocus)
+		ta.pickerReturnFocus = nil
+	}
+}
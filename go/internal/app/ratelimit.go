@@ -0,0 +1,27 @@
+package app
+
+import "time"
+
+// watchBackoff reports in the status bar when Reddit throttles requests
+// and the client holds them back before retrying.
+func (ta *TviewApp) watchBackoff() {
+	ta.client.OnBackoff(func(wait time.Duration) {
+		status := backoffStatus(time.Now().Add(wait), ta.client.Location())
+		ta.app.QueueUpdateDraw(func() {
+			ta.setStatus(status)
+		})
+	})
+}
+
+// backoffStatus is the status line while requests are held back. It names
+// the clock time of the retry rather than a count of seconds, which would
+// go stale as soon as it was drawn.
+func backoffStatus(retryAt time.Time, loc *time.Location) string {
+	return "Rate limited, retrying at " + retryAt.In(loc).Format("15:04:05")
+}
+
+// throttled reports whether requests are being held back after a 429, so
+// an auto-refresh tick can be skipped rather than queue up behind them.
+func (ta *TviewApp) throttled() bool {
+	return ta.client.Backoff() > 0
+}
@@ -0,0 +1,14 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffStatusShowsRetryTime(t *testing.T) {
+	retryAt := time.Date(2024, 5, 1, 14, 30, 5, 0, time.UTC)
+	got := backoffStatus(retryAt, time.UTC)
+	if want := "Rate limited, retrying at 14:30:05"; got != want {
+		t.Fatalf("backoffStatus = %q, want %q", got, want)
+	}
+}
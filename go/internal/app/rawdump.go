@@ -0,0 +1,57 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// dumpRawJSON saves the raw Reddit JSON for the current thread next to the
+// debug log. It is only available with debug_logging enabled.
+func (ta *TviewApp) dumpRawJSON() {
+	if !ta.cfg.DebugLogging {
+		ta.setStatus("Raw JSON dump needs debug_logging enabled in app_config.json")
+		return
+	}
+	if ta.currentThread == nil {
+		return
+	}
+	thread := *ta.currentThread
+	commentSort := ta.commentSort
+	ta.setStatus("Fetching raw JSON...")
+
+	go func() {
+		raw, err := ta.client.FetchRawSorted(thread.Permalink, commentSort)
+		var path string
+		if err == nil {
+			path, err = writeRawDump(thread.ID, raw, time.Now())
+		}
+		ta.app.QueueUpdateDraw(func() {
+			if err != nil {
+				ta.setStatus(fmt.Sprintf("Raw JSON dump failed: %v", err))
+				return
+			}
+			ta.setStatus(fmt.Sprintf("Raw JSON saved to %s", path))
+		})
+	}()
+}
+
+// writeRawDump writes raw, indented when it is valid JSON, to
+// reddit_stream_raw_<threadID>_<time>.json in the working directory.
+func writeRawDump(threadID string, raw []byte, now time.Time) (string, error) {
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, raw, "", "  "); err == nil {
+		raw = pretty.Bytes()
+	}
+	name := fmt.Sprintf("reddit_stream_raw_%s_%s.json", threadID, now.Format("20060102-150405"))
+	if err := os.WriteFile(name, raw, 0o644); err != nil {
+		return "", err
+	}
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs, nil
+	}
+	return name, nil
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// recapTopComments is how many top-level comments a recap includes.
+const recapTopComments = 10
+
+// recapMarkdown renders a short markdown summary of a thread in the export
+// format: its title and link, the OP text, and the highest-scoring
+// top-level comments without their replies.
+func recapMarkdown(thread reddit.Thread, post reddit.Post, comments []reddit.Comment) string {
+	var b strings.Builder
+	_ = writeMarkdownHeader(&b, thread, post)
+
+	var roots []*commentNode
+	for _, c := range comments {
+		if c.ParentID == "" {
+			roots = append(roots, &commentNode{comment: c})
+		}
+	}
+	sort.SliceStable(roots, func(i, j int) bool { return roots[i].comment.Score > roots[j].comment.Score })
+	if len(roots) > recapTopComments {
+		roots = roots[:recapTopComments]
+	}
+	if len(roots) == 0 {
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "\n## Top comments (%d total)\n\n", len(comments))
+	_ = writeMarkdownTree(&b, roots)
+	return b.String()
+}
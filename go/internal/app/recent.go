@@ -0,0 +1,46 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// maxRecentThreads is how many threads the 1–9 keys can switch between.
+const maxRecentThreads = 9
+
+// recentThread is an entry of the recent list, with the menu item that
+// listed it (nil when opened by URL) so reopening keeps its settings.
+type recentThread struct {
+	reddit.Thread
+	menu *config.MenuItem
+}
+
+// rememberThread moves thread to the front of the recent list, so 1 is
+// always the thread on screen and 2 the one before it.
+func (ta *TviewApp) rememberThread(thread reddit.Thread, menu *config.MenuItem) {
+	recent := []recentThread{{thread, menu}}
+	for _, t := range ta.recentThreads {
+		if t.ID != thread.ID && len(recent) < maxRecentThreads {
+			recent = append(recent, t)
+		}
+	}
+	ta.recentThreads = recent
+}
+
+// switchRecent reopens the n-th (1-based) recently opened thread in the
+// comments view, fetching it fresh.
+func (ta *TviewApp) switchRecent(n int) {
+	if n < 1 || n > len(ta.recentThreads) {
+		ta.setStatus(fmt.Sprintf("No recent thread %d", n))
+		return
+	}
+	entry := ta.recentThreads[n-1]
+	if ta.currentThread != nil && ta.currentThread.ID == entry.ID {
+		ta.setStatus("Already showing " + entry.Title)
+		return
+	}
+	thread := entry.Thread
+	ta.openThread(&thread, entry.menu)
+}
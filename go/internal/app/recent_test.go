@@ -0,0 +1,45 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestRememberThreadMostRecentFirst(t *testing.T) {
+	ta := &TviewApp{}
+	for i := 0; i < maxRecentThreads+3; i++ {
+		ta.rememberThread(reddit.Thread{ID: fmt.Sprint(i)}, nil)
+	}
+	if len(ta.recentThreads) != maxRecentThreads {
+		t.Fatalf("kept %d threads, want %d", len(ta.recentThreads), maxRecentThreads)
+	}
+	if ta.recentThreads[0].ID != "11" || ta.recentThreads[1].ID != "10" {
+		t.Errorf("recent = %v, want newest first", ta.recentThreads)
+	}
+
+	// Reopening a thread moves it to the front without duplicating it
+	ta.rememberThread(reddit.Thread{ID: "10"}, nil)
+	if ta.recentThreads[0].ID != "10" || ta.recentThreads[1].ID != "11" || len(ta.recentThreads) != maxRecentThreads {
+		t.Errorf("recent = %v, want 10 then 11", ta.recentThreads)
+	}
+}
+
+func TestRecentThreadKeepsItsMenuItem(t *testing.T) {
+	ta := &TviewApp{cfg: config.AppConfig{HighlightKeywords: []string{"goal"}}}
+	item := &config.MenuItem{Title: "Match", HighlightKeywords: []string{"penalty"}}
+	ta.rememberThread(reddit.Thread{ID: "a"}, item)
+	ta.rememberThread(reddit.Thread{ID: "b"}, nil)
+
+	if ta.recentThreads[1].menu != item {
+		t.Fatal("the recent entry lost the item that listed it")
+	}
+	if st := ta.newCommentView(ta.recentThreads[1].menu); !st.keywords.MatchString("penalty") {
+		t.Error("reopening a listed thread should keep its item's keywords")
+	}
+	if st := ta.newCommentView(ta.recentThreads[0].menu); st.keywords.MatchString("penalty") || !st.keywords.MatchString("goal") {
+		t.Error("a thread opened by URL should only get the global keywords")
+	}
+}
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+)
+
+// configPollInterval is how often watch_menu_config checks the file.
+const configPollInterval = 2 * time.Second
+
+// fileStamp identifies one version of a file; the zero value means the
+// file does not exist.
+type fileStamp struct {
+	path string
+	mod  time.Time
+	size int64
+}
+
+func menuConfigStamp(name string) fileStamp {
+	path := config.ResolveConfigPath(name)
+	info, err := os.Stat(path)
+	if path == "" || err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{path: path, mod: info.ModTime(), size: info.Size()}
+}
+
+// SetMenuConfigPath sets the menu config that R and watch_menu_config
+// reload, as resolved by config.ProfileMenuPath. The default menu config
+// is used until it is called.
+func (ta *TviewApp) SetMenuConfigPath(path string) {
+	ta.menuConfigPath.Store(path)
+}
+
+// menuPath returns the menu config currently in use.
+func (ta *TviewApp) menuPath() string {
+	if path, ok := ta.menuConfigPath.Load().(string); ok && path != "" {
+		return path
+	}
+	return config.MenuConfigPath
+}
+
+// watchMenuConfig polls the menu config and reloads it whenever it
+// changes. It runs for the life of the app; switching profiles moves it
+// to the new file.
+func (ta *TviewApp) watchMenuConfig() {
+	last := menuConfigStamp(ta.menuPath())
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		stamp := menuConfigStamp(ta.menuPath())
+		if stamp == last {
+			continue
+		}
+		last = stamp
+		ta.app.QueueUpdateDraw(ta.reloadMenuConfig)
+	}
+}
+
+// reloadMenuConfig re-reads and validates the menu config and swaps in
+// its items. An unreadable or invalid file keeps the current menu and
+// shows why in the status bar.
+func (ta *TviewApp) reloadMenuConfig() {
+	if !ta.loadMenuConfig(ta.menuPath()) {
+		return
+	}
+	ta.setStatus(fmt.Sprintf("Menu config reloaded: %d items", len(ta.menuItems)))
+}
+
+// loadMenuConfig reads and validates the menu config at path and swaps in
+// its items, reporting whether it did. On failure the current menu stays
+// and the status bar says why.
+func (ta *TviewApp) loadMenuConfig(path string) bool {
+	cfg, err := config.LoadMenuConfig(path)
+	if err == nil {
+		err = config.ValidateMenuConfig(cfg).Err()
+	}
+	if err != nil {
+		msg := strings.ReplaceAll(err.Error(), "\n  ", " ")
+		ta.setStatus(fmt.Sprintf("Menu config not loaded: %s", msg))
+		return false
+	}
+	ta.setMenuItems(cfg.MenuItems)
+	return true
+}
+
+// showProfilePicker lists the profiles in the config directories and
+// switches the menu to the chosen one.
+func (ta *TviewApp) showProfilePicker() {
+	names := config.Profiles()
+	if len(names) == 0 {
+		ta.setStatus("No profiles found — add menu configs to config/profiles/")
+		return
+	}
+	names = append([]string{""}, names...)
+	items := make([]pickerItem, len(names))
+	for i, name := range names {
+		items[i] = pickerItem{label: name}
+		if name == "" {
+			items[i] = pickerItem{label: "default", detail: "menu_config"}
+		}
+		if config.ProfileMenuPath(name) == ta.menuPath() {
+			items[i].detail = "current"
+		}
+	}
+	ta.showPicker("Profiles", items, func(idx int) {
+		path := config.ProfileMenuPath(names[idx])
+		if config.ResolveConfigPath(path) == "" && names[idx] != "" {
+			ta.setStatus(fmt.Sprintf("Profile %s no longer exists", names[idx]))
+			return
+		}
+		if !ta.loadMenuConfig(path) {
+			return
+		}
+		ta.SetMenuConfigPath(path)
+		ta.setStatus(fmt.Sprintf("Profile: %s (%d items)", items[idx].label, len(ta.menuItems)))
+	})
+}
+
+// setMenuItems replaces the menu, keeping the selection on the item with
+// the same title when there still is one.
+func (ta *TviewApp) setMenuItems(items []config.MenuItem) {
+	ta.menuIndex = keepMenuSelection(ta.menuItems, items, ta.menuIndex)
+	for _, pane := range []*CommentPane{ta.primaryPane, ta.secondaryPane} {
+		if pane != nil {
+			pane.menuIndex = keepMenuSelection(ta.menuItems, items, pane.menuIndex)
+		}
+	}
+	ta.menuItems = items
+	ta.renderMenu()
+	if ta.splitMode {
+		ta.rebuildSplitLayout()
+	}
+}
+
+// keepMenuSelection returns the index in items of the item selected at
+// idx in old, matched by title, or the first selectable item.
+func keepMenuSelection(old, items []config.MenuItem, idx int) int {
+	if idx >= 0 && idx < len(old) {
+		for i, item := range items {
+			if selectable(item) && item.Title == old[idx].Title {
+				return i
+			}
+		}
+	}
+	return firstMenuIndex(items)
+}
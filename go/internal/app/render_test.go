@@ -0,0 +1,114 @@
+package app
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rivo/tview"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/internal/theme"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// renderFixture is a small thread: two roots, a nested reply chain, a long
+// body that has to wrap, a list and a code block.
+var renderFixture = []reddit.Comment{
+	{ID: "a", Author: "alice", Body: "Kick-off! Here we go.", Score: 12, FormattedTime: "15:00"},
+	{ID: "b", Author: "bob", Body: "What a save by the keeper, honestly one of the best I have seen all season long.", Score: 5, FormattedTime: "15:02", ParentID: "a"},
+	{ID: "c", Author: "carol", Body: "Agreed:\n- reflexes\n- positioning", Score: 2, FormattedTime: "15:03", ParentID: "b"},
+	{ID: "d", Author: "dave", Body: "Lineups:\n\n    GK  Raya\n    CB  Saliba", ScoreHidden: true, FormattedTime: "15:04"},
+}
+
+func newRenderTestApp(cfg config.AppConfig) *TviewApp {
+	client := reddit.NewClient("test")
+	return &TviewApp{client: client, cfg: cfg, theme: theme.Default()}
+}
+
+// renderPlain renders comments at width and returns the text without
+// colour tags.
+func renderPlain(ta *TviewApp, comments []reddit.Comment, filter string, st *commentViewState, width int) string {
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetRect(0, 0, width, 200)
+	ta.renderCommentsToView(view, comments, filter, st)
+	return view.GetText(true)
+}
+
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", name, got, want)
+	}
+}
+
+func TestRenderGolden(t *testing.T) {
+	cases := []struct {
+		name   string
+		cfg    config.AppConfig
+		filter string
+		width  int
+		setup  func(st *commentViewState)
+	}{
+		{name: "nested_80", width: 80},
+		{name: "wrap_40", width: 40},
+		{name: "ascii_rule", cfg: config.AppConfig{IndentStyle: "ascii", CommentSeparator: "rule"}, width: 50},
+		{name: "filter", filter: "save", width: 80},
+		{name: "username", cfg: config.AppConfig{Username: "bob"}, width: 80},
+		{name: "filter_replies", filter: "+author:bob", width: 80},
+		{name: "collapsed_one", width: 80, setup: func(st *commentViewState) {
+			st.collapsed = map[string]bool{"b": true}
+		}},
+		{name: "collapsed_new", width: 80, setup: func(st *commentViewState) {
+			st.collapsed = map[string]bool{"a": true}
+			st.seen = map[string]bool{"a": true, "b": true, "c": true}
+		}},
+		{name: "op_badge", width: 80, setup: func(st *commentViewState) {
+			st.post.Author = "bob"
+		}},
+		{name: "link_post", width: 80, setup: func(st *commentViewState) {
+			st.post.URL = "https://example.com/match-report"
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ta := newRenderTestApp(c.cfg)
+			var st commentViewState
+			if c.setup != nil {
+				c.setup(&st)
+			}
+			checkGolden(t, c.name, renderPlain(ta, renderFixture, c.filter, &st, c.width))
+		})
+	}
+}
+
+func TestLoadMoreHintOnlyInSingleView(t *testing.T) {
+	ta := newRenderTestApp(config.AppConfig{})
+	comments := []reddit.Comment{{ID: "a", Author: "alice", Body: "hi", MoreChildren: []string{"b"}}}
+
+	if got := renderPlain(ta, comments, "", &commentViewState{}, 80); strings.Contains(got, "press m") {
+		t.Errorf("a split pane should not offer m:\n%s", got)
+	}
+
+	ta.commentsView = tview.NewTextView().SetDynamicColors(true)
+	ta.commentsView.SetRect(0, 0, 80, 200)
+	ta.renderCommentsToView(ta.commentsView, comments, "", &commentViewState{})
+	if got := ta.commentsView.GetText(true); !strings.Contains(got, "select and press m") {
+		t.Errorf("the single view should offer m:\n%s", got)
+	}
+}
@@ -0,0 +1,29 @@
+package app
+
+import "context"
+
+// requestScope ties in-flight requests to the thread a view is showing.
+// Cancelling it when the view moves on (another thread, another sort, back
+// to a list) aborts those requests, so a late answer can't overwrite the
+// newer thread's data.
+type requestScope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// context returns the scope's context, starting a new one after cancel.
+// Call it on the UI goroutine before handing it to a request goroutine.
+func (s *requestScope) context() context.Context {
+	if s.ctx == nil {
+		s.ctx, s.cancel = context.WithCancel(context.Background())
+	}
+	return s.ctx
+}
+
+// cancelAll aborts every request started under the current context.
+func (s *requestScope) cancelAll() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.ctx, s.cancel = nil, nil
+}
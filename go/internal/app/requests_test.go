@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRequestScopeCancelAll(t *testing.T) {
+	var s requestScope
+	old := s.context()
+	if s.context() != old {
+		t.Fatal("context() should reuse the live context")
+	}
+	s.cancelAll()
+	if !errors.Is(old.Err(), context.Canceled) {
+		t.Errorf("old context err = %v, want context.Canceled", old.Err())
+	}
+	if next := s.context(); next == old || next.Err() != nil {
+		t.Error("context() after cancelAll should start a fresh, live context")
+	}
+	s.cancelAll()
+	s.cancelAll() // safe to repeat
+}
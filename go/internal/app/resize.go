@@ -0,0 +1,50 @@
+package app
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	splitRatioStep = 10
+	splitRatioMin  = 20
+	splitRatioMax  = 80
+	// splitFeedbackDelay is how long the ratio stays in the status bar
+	// before the pane key hints come back.
+	splitFeedbackDelay = 2 * time.Second
+)
+
+// primaryShare returns the percentage of the split given to the primary
+// pane. The zero value means an even split.
+func (ta *TviewApp) primaryShare() int {
+	if ta.splitRatio == 0 {
+		return 50
+	}
+	return ta.splitRatio
+}
+
+// resizeSplit grows (delta > 0) or shrinks the primary pane and shows the
+// new ratio briefly in the status bar.
+func (ta *TviewApp) resizeSplit(delta int) {
+	ratio := ta.primaryShare() + delta*splitRatioStep
+	if ratio < splitRatioMin {
+		ratio = splitRatioMin
+	}
+	if ratio > splitRatioMax {
+		ratio = splitRatioMax
+	}
+	ta.splitRatio = ratio
+	ta.rebuildSplitLayout()
+
+	ta.setStatus(fmt.Sprintf("Split %d/%d", ratio, 100-ratio))
+	ta.resizeSeq++
+	seq := ta.resizeSeq
+	time.AfterFunc(splitFeedbackDelay, func() {
+		ta.app.QueueUpdateDraw(func() {
+			// Only restore if no later resize replaced the message
+			if seq == ta.resizeSeq && ta.splitMode {
+				ta.updateSplitHeader()
+			}
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// failedOp remembers the last fetch that failed so it can be re-run with the
+// same parameters instead of navigating back to it.
+type failedOp struct {
+	label string
+	run   func()
+}
+
+// loadFailed reports err in the status bar and records run as the operation
+// ctrl+r repeats. A subreddit blocked by allowed_subreddits is not worth
+// retrying, so it is only reported.
+func (ta *TviewApp) loadFailed(label string, err error, run func()) {
+	if errors.Is(err, reddit.ErrSubredditNotAllowed) {
+		ta.lastFailed = nil
+		ta.setStatus(fmt.Sprintf("Blocked: %v (see allowed_subreddits)", err))
+		return
+	}
+	ta.lastFailed = &failedOp{label: label, run: run}
+	ta.setStatus(fmt.Sprintf("Error: %v — Ctrl+R to retry", err))
+}
+
+// loadSucceeded forgets the pending retry once an operation goes through.
+func (ta *TviewApp) loadSucceeded() {
+	ta.lastFailed = nil
+}
+
+// retryFailed re-runs the last failed fetch.
+func (ta *TviewApp) retryFailed() {
+	if ta.lastFailed == nil {
+		ta.setStatus("Nothing to retry")
+		return
+	}
+	op := ta.lastFailed
+	ta.lastFailed = nil
+	ta.setStatus(fmt.Sprintf("Retrying: %s...", op.label))
+	op.run()
+}
+
+// loadOffline reports that cached data is shown because the live fetch
+// failed, keeping run as the ctrl+r retry.
+func (ta *TviewApp) loadOffline(label string, cached *reddit.OfflineCopy, run func()) {
+	ta.lastFailed = &failedOp{label: label, run: run}
+	ta.setStatus(fmt.Sprintf("Offline (cached %s) — Ctrl+R to retry", timeAgo(float64(cached.FetchedAt.Unix()), time.Now())))
+}
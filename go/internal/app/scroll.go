@@ -0,0 +1,57 @@
+package app
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// vimScroll handles the vim-style scroll keys on the comments page: gg
+// (top), G (bottom, which resumes following new comments), and
+// ctrl+d/ctrl+u (half a page down/up). A single g waits for the next key.
+// It reports whether event was consumed.
+func (ta *TviewApp) vimScroll(pageName string, event *tcell.EventKey) bool {
+	pending := ta.pendingG
+	ta.pendingG = false
+	if pageName != "comments" {
+		return false
+	}
+	view := ta.commentsView
+	if ta.splitMode {
+		pane := ta.getActivePane()
+		if pane == nil || pane.showingMenu || pane.showingThreads {
+			return false
+		}
+		view = pane.view
+	}
+
+	switch event.Key() {
+	case tcell.KeyCtrlD:
+		scrollHalfPage(view, 1)
+		return true
+	case tcell.KeyCtrlU:
+		scrollHalfPage(view, -1)
+		return true
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'g':
+			if pending {
+				view.ScrollToBeginning()
+			} else {
+				ta.pendingG = true
+			}
+			return true
+		case 'G':
+			view.ScrollToEnd()
+			return true
+		}
+	}
+	return false
+}
+
+// scrollHalfPage moves view by half its height, down for delta > 0.
+func scrollHalfPage(view *tview.TextView, delta int) {
+	row, col := view.GetScrollOffset()
+	_, _, _, height := view.GetInnerRect()
+	row = max(row+delta*max(height/2, 1), 0)
+	view.ScrollTo(row, col)
+}
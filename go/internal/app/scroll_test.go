@@ -0,0 +1,60 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func newScrollTestApp() *TviewApp {
+	view := tview.NewTextView()
+	view.SetRect(0, 0, 80, 10)
+	var b strings.Builder
+	for i := range 100 {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	view.SetText(b.String())
+	view.ScrollTo(50, 0)
+	return &TviewApp{commentsView: view}
+}
+
+func runeKey(r rune) *tcell.EventKey {
+	return tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)
+}
+
+func TestVimScrollGG(t *testing.T) {
+	ta := newScrollTestApp()
+	if !ta.vimScroll("comments", runeKey('g')) {
+		t.Fatal("first g should be consumed")
+	}
+	if row, _ := ta.commentsView.GetScrollOffset(); row != 50 {
+		t.Fatalf("a single g scrolled to %d", row)
+	}
+	if ta.vimScroll("comments", runeKey('n')) {
+		t.Error("n after g should fall through")
+	}
+	ta.vimScroll("comments", runeKey('g'))
+	ta.vimScroll("comments", runeKey('g'))
+	if row, _ := ta.commentsView.GetScrollOffset(); row != 0 {
+		t.Errorf("gg scrolled to %d, want 0", row)
+	}
+	if ta.vimScroll("threads", runeKey('g')) || ta.pendingG {
+		t.Error("g outside the comments page should be ignored")
+	}
+}
+
+func TestVimScrollHalfPage(t *testing.T) {
+	ta := newScrollTestApp()
+	ta.vimScroll("comments", tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl))
+	if row, _ := ta.commentsView.GetScrollOffset(); row != 55 {
+		t.Errorf("ctrl+d scrolled to %d, want 55", row)
+	}
+	ta.vimScroll("comments", tcell.NewEventKey(tcell.KeyCtrlU, 0, tcell.ModCtrl))
+	ta.vimScroll("comments", tcell.NewEventKey(tcell.KeyCtrlU, 0, tcell.ModCtrl))
+	if row, _ := ta.commentsView.GetScrollOffset(); row != 45 {
+		t.Errorf("ctrl+u scrolled to %d, want 45", row)
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// selfTextCollapsed reports whether the OP self-text block is folded to a
+// one-line summary. The configured default can be flipped per thread.
+func (ta *TviewApp) selfTextCollapsed(st *commentViewState) bool {
+	return ta.cfg.CollapseSelfText != st.selfTextToggled
+}
+
+// toggleSelfText expands or collapses the OP self-text block.
+func (ta *TviewApp) toggleSelfText() {
+	if strings.TrimSpace(ta.post.SelfText) == "" {
+		if ta.post.URL != "" {
+			ta.setStatus("This is a link post — press L to open its link")
+		} else {
+			ta.setStatus("This post has no text")
+		}
+		return
+	}
+	ta.selfTextToggled = !ta.selfTextToggled
+	ta.renderComments()
+	if !ta.selfTextCollapsed(&ta.commentViewState) {
+		ta.commentsView.ScrollToBeginning()
+	}
+}
+
+// renderSelfText writes the OP self-text block that sits above the comment
+// tree, either in full or as a one-line summary when collapsed. Link posts
+// have no text, so their target URL is shown instead.
+func (ta *TviewApp) renderSelfText(out io.Writer, st *commentViewState, width int) {
+	text := strings.TrimSpace(st.post.SelfText)
+	if text == "" {
+		if st.post.URL != "" {
+			fmt.Fprintf(out, "[%s::b]▸ Link[-:-:-] [%s]%s[-]\n\n",
+				ta.theme.Accent.Hex, ta.theme.Muted.Hex, tview.Escape(st.post.URL))
+		}
+		return
+	}
+
+	if ta.selfTextCollapsed(st) {
+		lines := strings.Split(text, "\n")
+		summary := strings.TrimSpace(lines[0])
+		if max := width - 24; max > 10 && len([]rune(summary)) > max {
+			summary = string([]rune(summary)[:max]) + "…"
+		}
+		fmt.Fprintf(out, "[%s::b]▸ OP[-:-:-] [%s]%s (%d lines)[-]\n\n",
+			ta.theme.Accent.Hex, ta.theme.Muted.Hex, tview.Escape(summary), len(lines))
+		return
+	}
+
+	fmt.Fprintf(out, "[%s::b]▾ OP[-:-:-]\n", ta.theme.Accent.Hex)
+	bodyWidth := width - 2
+	if bodyWidth < 20 {
+		bodyWidth = 20
+	}
+	for _, line := range wrapBody(text, bodyWidth) {
+		if line == "" {
+			fmt.Fprintln(out)
+			continue
+		}
+		fmt.Fprintf(out, "[%s]%s[-]\n", ta.theme.Muted.Hex, tview.Escape(line))
+	}
+	fmt.Fprintf(out, "[%s]%s[-]\n\n", ta.theme.Subtle.Hex, strings.Repeat("─", bodyWidth))
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// sinceOpened returns the comments posted after the thread was opened,
+// i.e. those missing from the first load. Replies whose parent is older
+// are drawn as roots.
+func (s *commentViewState) sinceOpened(comments []reddit.Comment) []reddit.Comment {
+	if s.opened == nil {
+		return nil
+	}
+	var out []reddit.Comment
+	for _, c := range comments {
+		if !s.opened[c.ID] {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// visibleComments applies the catch-up mode to comments before rendering.
+func (s *commentViewState) visibleComments(comments []reddit.Comment) []reddit.Comment {
+	if !s.sinceOpen {
+		return comments
+	}
+	return s.sinceOpened(comments)
+}
+
+// toggleSinceOpen switches between the whole thread and only the comments
+// posted since it was opened, for catching up after stepping away.
+func (ta *TviewApp) toggleSinceOpen() {
+	if ta.currentThread == nil || ta.opened == nil {
+		return
+	}
+	ta.sinceOpen = !ta.sinceOpen
+	ta.selectedID = ""
+	ta.renderComments()
+	ta.updateHeader(ta.threadTitle(), commentsKeys)
+	if !ta.sinceOpen {
+		ta.setStatus("Showing all comments")
+		return
+	}
+	ta.setStatus(fmt.Sprintf("Showing %d comments posted since you opened the thread", len(ta.sinceOpened(ta.comments))))
+}
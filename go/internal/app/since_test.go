@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestSinceOpenedKeepsOpenTimeSnapshot(t *testing.T) {
+	var st commentViewState
+	now := time.Now()
+	first := []reddit.Comment{{ID: "a"}, {ID: "b", ParentID: "a"}}
+	st.trackArrivals(first, now)
+
+	later := append(first, reddit.Comment{ID: "c", ParentID: "a"}, reddit.Comment{ID: "d"})
+	st.trackArrivals(later, now)
+	st.markAllSeen(later) // reading new comments must not move the anchor
+
+	if got := st.visibleComments(later); len(got) != len(later) {
+		t.Fatalf("catch-up off: got %d comments, want all %d", len(got), len(later))
+	}
+	st.sinceOpen = true
+	got := st.visibleComments(later)
+	if len(got) != 2 || got[0].ID != "c" || got[1].ID != "d" {
+		t.Errorf("catch-up on: got %+v, want c and d", got)
+	}
+
+	roots := buildCommentTree(got, filterQuery{}, lowerCache{})
+	if len(roots) != 2 {
+		t.Errorf("a reply to an older comment should render as a root, got %d roots", len(roots))
+	}
+}
+
+func TestCollapseAllSinceOpened(t *testing.T) {
+	ta := newRenderTestApp(config.AppConfig{})
+	ta.commentsView = tview.NewTextView()
+	ta.statusBar = tview.NewTextView()
+	ta.mainFlex = tview.NewFlex()
+	ta.comments = []reddit.Comment{
+		{ID: "old"},
+		{ID: "new", ParentID: "old"},
+		{ID: "reply", ParentID: "new"},
+	}
+	ta.opened = map[string]bool{"old": true}
+	ta.sinceOpen = true
+
+	ta.collapseAll()
+	if len(ta.collapsed) != 1 || !ta.collapsed["new"] {
+		t.Errorf("collapsed = %v, want only new (old is hidden)", ta.collapsed)
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// sortCycle is the order the s key steps through comment sorts.
+var sortCycle = []reddit.CommentSort{
+	reddit.SortBest,
+	reddit.SortTop,
+	reddit.SortNew,
+	reddit.SortOld,
+	reddit.SortControversial,
+	reddit.SortQA,
+}
+
+// nextSort returns the sort after s in sortCycle; the empty default counts
+// as SortNew.
+func nextSort(s reddit.CommentSort) reddit.CommentSort {
+	if s == "" {
+		s = reddit.SortNew
+	}
+	for i, candidate := range sortCycle {
+		if candidate == s {
+			return sortCycle[(i+1)%len(sortCycle)]
+		}
+	}
+	return sortCycle[0]
+}
+
+// cycleSort switches the comment sort of the current thread (or the active
+// pane in split mode) and re-fetches it. The thread reopens the way a
+// fresh one would: at the top for ranked sorts, at the configured end for
+// new/old. Read markers are kept so re-sorting doesn't flag everything new.
+func (ta *TviewApp) cycleSort() {
+	if ta.splitMode {
+		pane := ta.getActivePane()
+		if pane == nil || pane.thread == nil {
+			return
+		}
+		pane.requests.cancelAll()
+		pane.sort = nextSort(pane.sort)
+		ta.setStatus(fmt.Sprintf("Sorting by %s...", pane.sort.Label()))
+		ta.loadCommentsForPane(pane)
+		return
+	}
+
+	if ta.currentThread == nil {
+		return
+	}
+	ta.cancelRequests()
+	ta.commentSort = nextSort(ta.commentSort)
+	ta.comments = nil
+	ta.selectedID = ""
+	ta.jumpStack = nil
+	ta.commentsView.Clear()
+	ta.updateHeader(ta.threadTitle(), commentsKeys)
+	ta.setStatus(fmt.Sprintf("Sorting by %s...", ta.commentSort.Label()))
+	ta.loadComments()
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestNextSort(t *testing.T) {
+	cases := map[reddit.CommentSort]reddit.CommentSort{
+		"":                       reddit.SortOld,
+		reddit.SortNew:           reddit.SortOld,
+		reddit.SortControversial: reddit.SortQA,
+		reddit.SortBest:          reddit.SortTop,
+		reddit.SortQA:            reddit.SortBest,
+	}
+	for in, want := range cases {
+		if got := nextSort(in); got != want {
+			t.Errorf("nextSort(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
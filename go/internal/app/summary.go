@@ -0,0 +1,45 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// threadSummary is the compact header line for the current thread:
+// "r/soccer · Match Thread · 412 comments · 2h ago". Parts that aren't
+// known, such as the age of a thread opened by URL, are left out.
+func (ta *TviewApp) threadSummary(now time.Time) string {
+	thread := ta.currentThread
+	var parts []string
+	if sub := reddit.PermalinkSubreddit(thread.Permalink); sub != "" {
+		parts = append(parts, "r/"+sub)
+	}
+	parts = append(parts, thread.Title)
+
+	count := thread.NumComments
+	if len(ta.comments) > count {
+		count = len(ta.comments)
+	}
+	if count == 1 {
+		parts = append(parts, "1 comment")
+	} else {
+		parts = append(parts, fmt.Sprintf("%d comments", count))
+	}
+	if age := timeAgo(thread.CreatedUTC, now); age != "" {
+		parts = append(parts, age)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// toggleCompactHeader switches the comments header between the thread
+// title and the one-line summary.
+func (ta *TviewApp) toggleCompactHeader() {
+	if ta.currentThread == nil {
+		return
+	}
+	ta.compactHeader = !ta.compactHeader
+	ta.updateHeader(ta.threadTitle(), commentsKeys)
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestThreadSummary(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	ta := &TviewApp{currentThread: &reddit.Thread{
+		Title:       "Match Thread",
+		Permalink:   "/r/soccer/comments/abc123/match_thread/",
+		NumComments: 412,
+		CreatedUTC:  float64(now.Add(-2 * time.Hour).Unix()),
+	}}
+	if got, want := ta.threadSummary(now), "r/soccer · Match Thread · 412 comments · 2h ago"; got != want {
+		t.Errorf("threadSummary = %q, want %q", got, want)
+	}
+
+	// A thread opened by URL has no listing metadata; the loaded comments
+	// stand in for the count and the age is left out.
+	ta.currentThread = &reddit.Thread{Title: "Daily Discussion", Permalink: "/r/nfl/comments/xyz/daily/"}
+	ta.comments = []reddit.Comment{{ID: "a"}}
+	if got, want := ta.threadSummary(now), "r/nfl · Daily Discussion · 1 comment"; got != want {
+		t.Errorf("threadSummary = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// tableSep separates table columns when rendered.
+const tableSep = " │ "
+
+// splitTableRow splits a markdown table row into trimmed cells, dropping
+// the optional leading and trailing pipes.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+	cells := strings.Split(line, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// tableAlign parses a markdown table separator row ("---|:--:|--:") and
+// reports which columns are right-aligned. ok is false if line is not a
+// separator row.
+func tableAlign(line string) (right []bool, ok bool) {
+	if !strings.Contains(line, "-") {
+		return nil, false
+	}
+	for _, cell := range splitTableRow(line) {
+		dashes := strings.Trim(cell, ":")
+		if dashes == "" || strings.Trim(dashes, "-") != "" {
+			return nil, false
+		}
+		right = append(right, strings.HasSuffix(cell, ":") && !strings.HasPrefix(cell, ":"))
+	}
+	return right, true
+}
+
+// tableAt reports whether a markdown table starts at lines[i]: a row with
+// pipes followed by a separator row with the same number of columns.
+func tableAt(lines []string, i int) bool {
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") {
+		return false
+	}
+	right, ok := tableAlign(lines[i+1])
+	return ok && len(right) == len(splitTableRow(lines[i]))
+}
+
+// renderTable lays out a markdown table (header, separator and body rows)
+// as aligned columns no wider than width, truncating cells that overflow.
+func renderTable(header string, sep string, rows []string, width int) []string {
+	right, _ := tableAlign(sep)
+	cols := len(right)
+	cells := [][]string{fitCells(splitTableRow(header), cols)}
+	for _, row := range rows {
+		cells = append(cells, fitCells(splitTableRow(row), cols))
+	}
+
+	widths := make([]int, cols)
+	for _, row := range cells {
+		for c, cell := range row {
+			if n := uniseg.StringWidth(cell); n > widths[c] {
+				widths[c] = n
+			}
+		}
+	}
+	shrinkColumns(widths, width-(cols-1)*len([]rune(tableSep)))
+
+	out := []string{formatTableRow(cells[0], widths, right)}
+	rule := make([]string, cols)
+	for c, w := range widths {
+		rule[c] = strings.Repeat("─", w)
+	}
+	out = append(out, strings.Join(rule, "─┼─"))
+	for _, row := range cells[1:] {
+		out = append(out, formatTableRow(row, widths, right))
+	}
+	return out
+}
+
+// fitCells pads or trims a row to exactly cols cells.
+func fitCells(cells []string, cols int) []string {
+	for len(cells) < cols {
+		cells = append(cells, "")
+	}
+	return cells[:cols]
+}
+
+// shrinkColumns narrows the widest column one cell at a time until the
+// columns fit in avail, leaving every column at least one cell wide.
+func shrinkColumns(widths []int, avail int) {
+	for {
+		total, widest := 0, 0
+		for c, w := range widths {
+			total += w
+			if w > widths[widest] {
+				widest = c
+			}
+		}
+		if total <= avail || widths[widest] <= 1 {
+			return
+		}
+		widths[widest]--
+	}
+}
+
+func formatTableRow(cells []string, widths []int, right []bool) string {
+	parts := make([]string, len(cells))
+	for c, cell := range cells {
+		cell = truncateWidth(cell, widths[c])
+		pad := strings.Repeat(" ", widths[c]-uniseg.StringWidth(cell))
+		if right[c] {
+			parts[c] = pad + cell
+		} else {
+			parts[c] = cell + pad
+		}
+	}
+	return strings.TrimRight(strings.Join(parts, tableSep), " ")
+}
+
+// truncateWidth shortens s to at most width terminal cells, ending it with
+// "…" when anything was cut. Wide characters are never split.
+func truncateWidth(s string, width int) string {
+	if uniseg.StringWidth(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	used, state := 0, -1
+	for rest := s; rest != ""; {
+		var cluster string
+		var w int
+		cluster, rest, w, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if used+w > width-1 {
+			break
+		}
+		b.WriteString(cluster)
+		used += w
+	}
+	return b.String() + "…"
+}
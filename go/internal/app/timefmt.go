@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+// Comment time display modes for the time_display option.
+const (
+	timeAbsolute = "absolute"
+	timeRelative = "relative"
+	timeBoth     = "both"
+)
+
+// normalizeTimeDisplay maps a time_display value to a known mode,
+// defaulting to absolute.
+func normalizeTimeDisplay(mode string) string {
+	switch m := strings.ToLower(strings.TrimSpace(mode)); m {
+	case timeRelative, timeBoth:
+		return m
+	}
+	return timeAbsolute
+}
+
+// nextTimeDisplay returns the mode after mode in the order the d key
+// cycles through: absolute, relative, both.
+func nextTimeDisplay(mode string) string {
+	switch normalizeTimeDisplay(mode) {
+	case timeAbsolute:
+		return timeRelative
+	case timeRelative:
+		return timeBoth
+	}
+	return timeAbsolute
+}
+
+// cycleTimeDisplay switches comment times to the next display mode for
+// the rest of the session. Relative times are worked out from CreatedUTC
+// on every render, so they stay current as refreshes redraw the view.
+func (ta *TviewApp) cycleTimeDisplay() {
+	ta.cfg.TimeDisplay = nextTimeDisplay(ta.cfg.TimeDisplay)
+	if ta.splitMode {
+		ta.rebuildSplitLayout()
+	} else {
+		ta.renderComments()
+	}
+	ta.setStatus(fmt.Sprintf("Times: %s", ta.cfg.TimeDisplay))
+}
+
+// commentTime returns the time shown in a comment header for the given
+// display mode. formatted is the client's absolute timestamp; loc is used
+// for the short wall-clock time in "both" mode.
+func commentTime(mode string, ts float64, formatted string, loc *time.Location, now time.Time) string {
+	if ts == 0 {
+		return formatted
+	}
+	switch mode {
+	case timeRelative:
+		return timeAgo(ts, now)
+	case timeBoth:
+		age := strings.TrimSuffix(timeAgo(ts, now), " ago")
+		return fmt.Sprintf("%s (%s)", time.Unix(int64(ts), 0).In(loc).Format("15:04"), age)
+	}
+	return formatted
+}
+
+// timeAgo formats the time since ts (epoch seconds) relative to now, e.g.
+// "just now", "3m ago", "1h ago", "2d ago".
+func timeAgo(ts float64, now time.Time) string {
+	if ts == 0 {
+		return ""
+	}
+	d := now.Sub(time.Unix(int64(ts), 0))
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+	}
+}
+
+// editedLabel returns the "(edited ...)" marker for a comment header, or
+// empty string if the comment was not edited.
+func editedLabel(edited bool, editedUTC float64, now time.Time) string {
+	switch {
+	case !edited:
+		return ""
+	case editedUTC == 0:
+		return "(edited)"
+	default:
+		return fmt.Sprintf("(edited %s)", timeAgo(editedUTC, now))
+	}
+}
+
+// editedDetail describes when c was edited, for the status bar when an
+// edited comment is selected: "Edited at 15:04:05, 12m after posting".
+// It is empty unless the edit time is known.
+func editedDetail(c reddit.Comment, loc *time.Location) string {
+	if !c.Edited || c.EditedUTC == 0 {
+		return ""
+	}
+	at := time.Unix(int64(c.EditedUTC), 0).In(loc).Format("15:04:05")
+	if c.CreatedUTC == 0 || c.EditedUTC < c.CreatedUTC {
+		return "Edited at " + at
+	}
+	after := strings.TrimSuffix(timeAgo(c.CreatedUTC, time.Unix(int64(c.EditedUTC), 0)), " ago")
+	if after == "just now" {
+		return fmt.Sprintf("Edited at %s, moments after posting", at)
+	}
+	return fmt.Sprintf("Edited at %s, %s after posting", at, after)
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func TestCommentTime(t *testing.T) {
+	now := time.Date(2024, 5, 1, 15, 7, 0, 0, time.UTC)
+	ts := float64(now.Add(-3 * time.Minute).Unix())
+	formatted := "2024-05-01 15:04:00"
+
+	cases := map[string]string{
+		timeAbsolute: formatted,
+		timeRelative: "3m ago",
+		timeBoth:     "15:04 (3m)",
+	}
+	for mode, want := range cases {
+		if got := commentTime(mode, ts, formatted, time.UTC, now); got != want {
+			t.Errorf("commentTime(%q) = %q, want %q", mode, got, want)
+		}
+	}
+}
+
+func TestNormalizeTimeDisplay(t *testing.T) {
+	cases := map[string]string{
+		"":         timeAbsolute,
+		"Relative": timeRelative,
+		" both ":   timeBoth,
+		"bogus":    timeAbsolute,
+	}
+	for in, want := range cases {
+		if got := normalizeTimeDisplay(in); got != want {
+			t.Errorf("normalizeTimeDisplay(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestEditedDetail(t *testing.T) {
+	posted := float64(time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC).Unix())
+	cases := []struct {
+		c    reddit.Comment
+		want string
+	}{
+		{reddit.Comment{CreatedUTC: posted}, ""},
+		{reddit.Comment{CreatedUTC: posted, Edited: true}, ""},
+		{reddit.Comment{CreatedUTC: posted, Edited: true, EditedUTC: posted + 720}, "Edited at 15:12:00, 12m after posting"},
+		{reddit.Comment{CreatedUTC: posted, Edited: true, EditedUTC: posted + 20}, "Edited at 15:00:20, moments after posting"},
+		{reddit.Comment{Edited: true, EditedUTC: posted}, "Edited at 15:00:00"},
+	}
+	for _, tc := range cases {
+		if got := editedDetail(tc.c, time.UTC); got != tc.want {
+			t.Errorf("editedDetail(%+v) = %q, want %q", tc.c, got, tc.want)
+		}
+	}
+}
+
+func TestNextTimeDisplay(t *testing.T) {
+	mode := ""
+	var got []string
+	for range 4 {
+		mode = nextTimeDisplay(mode)
+		got = append(got, mode)
+	}
+	want := []string{timeRelative, timeBoth, timeAbsolute, timeRelative}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("cycle = %q, want %q", got, want)
+		}
+	}
+}
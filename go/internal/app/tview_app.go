@@ -1,24 +1,33 @@
 package app
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"github.com/rivo/uniseg"
 
 	"github.com/fenneh/reddit-stream-console/internal/config"
-	"github.com/fenneh/reddit-stream-console/internal/reddit"
 	"github.com/fenneh/reddit-stream-console/internal/theme"
+	"github.com/fenneh/reddit-stream-console/reddit"
 )
 
 // Version is set at build time via ldflags
 var Version = "dev"
 
+// Header key lists. {action} stands for the keys bound to a rebindable
+// action; see keyHelp.
+const commentsKeys = "{quit}:Quit  {refresh}/R:Refresh/Reload  f:Pause  {filter}:Filter  J/K:Select  gg/G:Top/Bottom  Enter/Space:Collapse  z/Z:Fold/Unfold  n/p:Next/Prev  N:Prev-match  @:Mentions  U:Parent  A:Authors  L:Links  m/M:More/Media  O:Browser  w/W:Save-md/json  y/Y/x/X:Copy-text/quote/link/thread  S:Sort  d:Time  c/C:Read/Catch-up  b:Summary  1-9:Recent  I:OP  E:Note  {split_horizontal}/{split_vertical}:Split  T:Theme  {back}:Back"
+
 func init() {
 	// Use single-line borders globally (both normal and focused)
 	tview.Borders.Horizontal = '─'
@@ -43,8 +52,10 @@ func init() {
 
 type TviewApp struct {
 	app          *tview.Application
+	screen       tcell.Screen // the screen app draws on, set by captureScreen
 	pages        *tview.Pages
 	header       *tview.TextView
+	authBadge    *tview.TextView // right end of the header row
 	menuView     *tview.TextView // Custom menu using TextView
 	menuIndex    int             // Current menu selection
 	threadView   *tview.TextView // Custom thread list using TextView
@@ -53,6 +64,7 @@ type TviewApp struct {
 	urlInput     *tview.InputField
 	filterInput  *tview.InputField
 	statusBar    *tview.TextView
+	matchBar     *tview.TextView // live score line under the header
 	mainFlex     *tview.Flex
 
 	// Wrapping flexes whose borders need re-theming on theme change
@@ -61,19 +73,43 @@ type TviewApp struct {
 	urlInnerFlex *tview.Flex
 
 	client        *reddit.Client
+	cfg           config.AppConfig
 	menuItems     []config.MenuItem
 	threadsData   []reddit.Thread
 	comments      []reddit.Comment
 	currentThread *reddit.Thread
 	currentMenu   *config.MenuItem
+	notes         config.Notes
+	history       []string // recent URL input entries, most recent first
 
 	theme         theme.Theme
 	startupNotice string // shown briefly in the status bar at launch
+	headerTitle   string // last title drawn by writeHeader
+
+	pickerReturnFocus tview.Primitive // focus to restore when a picker closes
+	lastFailed        *failedOp       // fetch repeated by ctrl+r, nil if none
 
 	filterActive   bool
+	promptActive   bool
+	hideScores     bool
+	commentSort    reddit.CommentSort // server-side order of the comments page, "" = new
 	commentFilter  string
+	filterSeq      int // bumped per filter change so stale debounced renders do nothing
 	refreshEnabled bool
+	refreshPaused  bool // ticks skip the fetch until toggled back with f
+	pendingG       bool // a g was pressed; a second one scrolls to the top
+	keys           keymap
+	menuConfigPath atomic.Value // string, the menu config in use; see SetMenuConfigPath
 	stopRefresh    chan struct{}
+	lastInput      atomic.Int64   // unix nanos of the last keystroke
+	idlePaused     bool           // refresh skipped until the next keystroke
+	compactHeader  bool           // comments header shows the one-line thread summary
+	notifyFailed   bool           // a desktop notification failed; don't try again
+	recentThreads  []recentThread // most recently opened first, for the 1–9 keys
+	requests       requestScope   // requests for currentThread, cancelled on leaving it
+	commentViewState
+
+	navStack []string // pages visited from the menu, current last; Esc pops
 
 	latestVersion string // Latest version from GitHub, empty if current or unknown
 
@@ -82,20 +118,31 @@ type TviewApp struct {
 	secondaryPane  *CommentPane
 	activePaneID   string // "primary" or "secondary"
 	splitMode      bool
-	splitDirection int // tview.FlexRow (horizontal) or FlexColumn (vertical)
+	broadcast      bool // thread selection loads into every pane, one sort each
+	splitRatio     int  // primary pane share in percent, 0 = even
+	resizeSeq      int  // bumped per resize so stale feedback timers do nothing
+	splitDirection int  // tview.FlexRow (horizontal) or FlexColumn (vertical)
 }
 
-func NewTviewApp(menuItems []config.MenuItem, client *reddit.Client, t theme.Theme) *TviewApp {
+func NewTviewApp(menuItems []config.MenuItem, client *reddit.Client, t theme.Theme, cfg config.AppConfig) *TviewApp {
 	ta := &TviewApp{
 		app:         tview.NewApplication(),
 		pages:       tview.NewPages(),
 		menuItems:   menuItems,
 		client:      client,
+		cfg:         cfg,
+		hideScores:  cfg.HideScores,
+		notes:       config.LoadNotes(),
+		history:     config.LoadHistory(),
 		theme:       t,
 		stopRefresh: make(chan struct{}),
 	}
 
+	ta.lastInput.Store(time.Now().UnixNano())
 	ta.setupUI()
+	ta.captureScreen()
+	ta.watchAuth()
+	ta.watchBackoff()
 	return ta
 }
 
@@ -112,17 +159,20 @@ func (ta *TviewApp) setupUI() {
 		SetTextAlign(tview.AlignLeft)
 	ta.header.SetBackgroundColor(ta.theme.HeaderBg.TCell)
 	ta.header.SetTextColor(ta.theme.HeaderFg.TCell)
+	ta.authBadge = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignRight)
+	ta.authBadge.SetBackgroundColor(ta.theme.HeaderBg.TCell)
+	headerRow := tview.NewFlex().
+		AddItem(ta.header, 0, 1, false).
+		AddItem(ta.authBadge, authBadgeWidth, 0, false)
 
 	// Custom menu using TextView for full control
 	ta.menuView = tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 	ta.menuView.SetBackgroundColor(tcell.ColorDefault)
-	ta.menuIndex = 0
-	// Skip to first non-separator
-	for ta.menuIndex < len(ta.menuItems) && ta.menuItems[ta.menuIndex].Type == "separator" {
-		ta.menuIndex++
-	}
+	ta.menuIndex = firstMenuIndex(ta.menuItems)
 
 	// Thread list - custom TextView like menu
 	ta.threadView = tview.NewTextView().
@@ -131,6 +181,7 @@ func (ta *TviewApp) setupUI() {
 		SetTextAlign(tview.AlignCenter)
 	ta.threadView.SetBackgroundColor(tcell.ColorDefault)
 	ta.threadIndex = 0
+	ta.enableClicks()
 
 	// Comments view - this is the key component with built-in scrolling
 	ta.commentsView = tview.NewTextView().
@@ -170,8 +221,13 @@ func (ta *TviewApp) setupUI() {
 	ta.buildURLInputPage()
 
 	// Set up main layout
+	// Pinned score line for match threads, hidden until there is something to show
+	ta.matchBar = tview.NewTextView().SetDynamicColors(true)
+	ta.matchBar.SetBackgroundColor(tcell.ColorDefault)
+
 	ta.mainFlex = tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(ta.header, 1, 0, false).
+		AddItem(headerRow, 1, 0, false).
+		AddItem(ta.matchBar, 0, 0, false).
 		AddItem(ta.pages, 0, 1, true).
 		AddItem(ta.statusBar, 1, 0, false)
 
@@ -197,6 +253,11 @@ func (ta *TviewApp) buildMenuPage() {
 func (ta *TviewApp) renderMenu() {
 	ta.menuView.Clear()
 
+	if ta.menuIndex == -1 {
+		fmt.Fprintf(ta.menuView, "\n[%s]%s[-]", ta.theme.Muted.Hex, noSelectableItems)
+		return
+	}
+
 	var lines []string
 	lines = append(lines, "") // Top padding
 
@@ -207,12 +268,12 @@ func (ta *TviewApp) renderMenu() {
 		}
 
 		if i == ta.menuIndex {
-			lines = append(lines, fmt.Sprintf("[%s::b]→ %s[-:-:-]", ta.theme.Accent.Hex, item.Title))
+			lines = append(lines, fmt.Sprintf("[\"%s\"][%s::b]→ %s[-:-:-][\"\"]", clickRegion(menuRegion, i), ta.theme.Accent.Hex, item.Title))
 			if item.Description != "" {
 				lines = append(lines, fmt.Sprintf("[%s]  %s[-]", ta.theme.Muted.Hex, item.Description))
 			}
 		} else {
-			lines = append(lines, fmt.Sprintf("[%s]  %s[-]", ta.theme.Secondary.Hex, item.Title))
+			lines = append(lines, fmt.Sprintf("[\"%s\"][%s]  %s[-][\"\"]", clickRegion(menuRegion, i), ta.theme.Secondary.Hex, item.Title))
 			if item.Description != "" {
 				lines = append(lines, fmt.Sprintf("[%s]  %s[-]", ta.theme.Subtle.Hex, item.Description))
 			}
@@ -223,36 +284,12 @@ func (ta *TviewApp) renderMenu() {
 }
 
 func (ta *TviewApp) menuUp() {
-	orig := ta.menuIndex
-	for {
-		ta.menuIndex--
-		if ta.menuIndex < 0 {
-			ta.menuIndex = len(ta.menuItems) - 1
-		}
-		if ta.menuIndex == orig {
-			break // Wrapped around
-		}
-		if ta.menuItems[ta.menuIndex].Type != "separator" {
-			break
-		}
-	}
+	ta.menuIndex = stepMenuIndex(ta.menuItems, ta.menuIndex, -1)
 	ta.renderMenu()
 }
 
 func (ta *TviewApp) menuDown() {
-	orig := ta.menuIndex
-	for {
-		ta.menuIndex++
-		if ta.menuIndex >= len(ta.menuItems) {
-			ta.menuIndex = 0
-		}
-		if ta.menuIndex == orig {
-			break // Wrapped around
-		}
-		if ta.menuItems[ta.menuIndex].Type != "separator" {
-			break
-		}
-	}
+	ta.menuIndex = stepMenuIndex(ta.menuItems, ta.menuIndex, 1)
 	ta.renderMenu()
 }
 
@@ -279,10 +316,12 @@ func (ta *TviewApp) renderThreadList() {
 
 	var lines []string
 	for i, thread := range ta.threadsData {
+		note := ta.subredditTag(ta.currentMenu, thread) + ta.mediaTag(thread.MediaURL) + ta.noteSuffix(thread.ID)
+		region := clickRegion(threadRegion, i)
 		if i == ta.threadIndex {
-			lines = append(lines, fmt.Sprintf("[%s::b]→ %s[-:-:-]", ta.theme.Accent.Hex, thread.Title))
+			lines = append(lines, fmt.Sprintf("[\"%s\"][%s::b]→ %s[-:-:-]%s[\"\"]", region, ta.theme.Accent.Hex, thread.Title, note))
 		} else {
-			lines = append(lines, fmt.Sprintf("[%s]  %s[-]", ta.theme.Secondary.Hex, thread.Title))
+			lines = append(lines, fmt.Sprintf("[\"%s\"][%s]  %s[-]%s[\"\"]", region, ta.theme.Secondary.Hex, thread.Title, note))
 		}
 	}
 
@@ -292,6 +331,15 @@ func (ta *TviewApp) renderThreadList() {
 	ta.threadView.ScrollTo(ta.threadIndex, 0)
 }
 
+// subredditTag names thread's subreddit in the thread list when item
+// searches more than one.
+func (ta *TviewApp) subredditTag(item *config.MenuItem, thread reddit.Thread) string {
+	if item == nil || len(item.Subreddit) < 2 || thread.Subreddit == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]r/%s[-]", ta.theme.Muted.Hex, thread.Subreddit)
+}
+
 func (ta *TviewApp) threadUp() {
 	if len(ta.threadsData) == 0 {
 		return
@@ -326,7 +374,7 @@ func (ta *TviewApp) buildURLInputPage() {
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 	label.SetBackgroundColor(tcell.ColorDefault)
-	fmt.Fprintf(label, "[%s::b]Enter Reddit Thread URL[-:-:-]", ta.theme.Primary.Hex)
+	fmt.Fprintf(label, "[%s::b]Enter Reddit Thread URL or Subreddit[-:-:-]", ta.theme.Primary.Hex)
 
 	// Style the input field
 	ta.urlInput.SetBackgroundColor(tcell.ColorDefault)
@@ -334,8 +382,12 @@ func (ta *TviewApp) buildURLInputPage() {
 	ta.urlInput.SetFieldTextColor(ta.theme.Primary.TCell)
 	ta.urlInput.SetLabelColor(ta.theme.Accent.TCell)
 	ta.urlInput.SetLabel("→ ")
-	ta.urlInput.SetPlaceholder("https://reddit.com/r/...")
+	ta.urlInput.SetPlaceholder("https://reddit.com/r/... or r/subreddit")
 	ta.urlInput.SetPlaceholderTextColor(ta.theme.Placeholder.TCell)
+	ta.urlInput.SetAutocompleteStyles(ta.theme.InputBg.TCell,
+		tcell.StyleDefault.Foreground(ta.theme.Secondary.TCell),
+		tcell.StyleDefault.Foreground(ta.theme.Accent.TCell).Bold(true))
+	ta.urlInput.SetAutocompleteFunc(ta.urlSuggestions)
 
 	// Hint text
 	hint := tview.NewTextView().
@@ -375,43 +427,68 @@ func (ta *TviewApp) buildURLInputPage() {
 }
 
 func (ta *TviewApp) globalKeyHandler(event *tcell.EventKey) *tcell.EventKey {
+	ta.markInput()
+
 	// Get current page
 	pageName, _ := ta.pages.GetFrontPage()
 
-	// Don't intercept keys when in input fields
-	if pageName == "url" || ta.filterActive {
-		if event.Key() == tcell.KeyEscape {
+	// Pickers and text inputs get every key, so nothing global (like q)
+	// fires while the user is typing
+	if pageName == "picker" {
+		return event
+	}
+	if ta.inputFocused(pageName) {
+		if event.Key() == tcell.KeyEscape && !ta.promptActive {
 			if ta.filterActive {
 				ta.hideFilter()
 				return nil
 			}
-			ta.showMenu()
-			return nil
+			if pageName == "url" {
+				ta.navBack()
+				return nil
+			}
 		}
 		return event
 	}
 
+	if ta.vimScroll(pageName, event) {
+		return nil
+	}
+
+	if pageName == "welcome" {
+		switch event.Key() {
+		case tcell.KeyEnter, tcell.KeyEscape:
+			ta.closeWelcome()
+			return nil
+		case tcell.KeyRune:
+			if event.Rune() == 'w' || event.Rune() == 'W' {
+				ta.writeDefaultMenuConfig()
+				return nil
+			}
+		}
+	}
+
 	// Menu page navigation (non-split mode)
 	if pageName == "menu" && !ta.splitMode {
-		switch event.Key() {
-		case tcell.KeyUp:
+		switch {
+		case ta.bound(event, config.ActionMenuUp):
 			ta.menuUp()
 			return nil
-		case tcell.KeyDown:
+		case ta.bound(event, config.ActionMenuDown):
 			ta.menuDown()
 			return nil
-		case tcell.KeyEnter:
-			ta.selectMenuItem(ta.menuIndex)
+		case ta.bound(event, config.ActionSelect):
+			ta.selectMenuItem(ta.menuIndex, false)
+			return nil
+		case event.Key() == tcell.KeyRune && (event.Rune() == 'o' || event.Rune() == 'O'):
+			ta.selectMenuItem(ta.menuIndex, true)
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'R':
+			ta.reloadMenuConfig()
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'P':
+			ta.showProfilePicker()
 			return nil
-		case tcell.KeyRune:
-			switch event.Rune() {
-			case 'k', 'K':
-				ta.menuUp()
-				return nil
-			case 'j', 'J':
-				ta.menuDown()
-				return nil
-			}
 		}
 	}
 
@@ -420,63 +497,54 @@ func (ta *TviewApp) globalKeyHandler(event *tcell.EventKey) *tcell.EventKey {
 		pane := ta.getActivePane()
 		if pane != nil {
 			if pane.showingMenu {
-				switch event.Key() {
-				case tcell.KeyUp:
+				switch {
+				case ta.bound(event, config.ActionMenuUp):
 					ta.paneMenuUp(pane)
 					return nil
-				case tcell.KeyDown:
+				case ta.bound(event, config.ActionMenuDown):
 					ta.paneMenuDown(pane)
 					return nil
-				case tcell.KeyEnter:
+				case ta.bound(event, config.ActionSelect):
 					ta.paneSelectMenuItem(pane)
 					return nil
-				case tcell.KeyEscape:
+				case ta.bound(event, config.ActionBack):
 					// Close this pane and exit split mode
 					ta.closeSplitMode()
 					return nil
-				case tcell.KeyRune:
-					switch event.Rune() {
-					case 'k', 'K':
-						ta.paneMenuUp(pane)
-						return nil
-					case 'j', 'J':
-						ta.paneMenuDown(pane)
-						return nil
-					}
 				}
 			} else if pane.showingThreads {
-				switch event.Key() {
-				case tcell.KeyUp:
+				switch {
+				case ta.bound(event, config.ActionMenuUp):
 					ta.paneThreadUp(pane)
 					return nil
-				case tcell.KeyDown:
+				case ta.bound(event, config.ActionMenuDown):
 					ta.paneThreadDown(pane)
 					return nil
-				case tcell.KeyEnter:
+				case ta.bound(event, config.ActionSelect):
 					ta.paneSelectThread(pane)
 					return nil
-				case tcell.KeyEscape:
+				case ta.bound(event, config.ActionBack):
+					if pane.detail != nil {
+						ta.closeSplitMode()
+						return nil
+					}
 					// Go back to menu in this pane
 					pane.showingThreads = false
 					pane.showingMenu = true
 					ta.rebuildSplitLayout()
 					return nil
-				case tcell.KeyRune:
-					switch event.Rune() {
-					case 'k', 'K':
-						ta.paneThreadUp(pane)
-						return nil
-					case 'j', 'J':
-						ta.paneThreadDown(pane)
-						return nil
-					}
 				}
 			} else {
 				// Showing comments in this pane
-				switch event.Key() {
-				case tcell.KeyEscape:
+				if ta.bound(event, config.ActionBack) {
+					// A linked detail pane hands focus back to its list
+					if ta.masterOf(pane) != nil {
+						ta.switchActivePane()
+						return nil
+					}
 					// Go back to threads in this pane
 					pane.showingThreads = true
+					pane.requests.cancelAll()
 					pane.thread = nil
 					pane.comments = nil
 					// Stop refresh for this pane
@@ -496,68 +564,281 @@ func (ta *TviewApp) globalKeyHandler(event *tcell.EventKey) *tcell.EventKey {
 
 	// Thread list navigation
 	if pageName == "threads" {
-		switch event.Key() {
-		case tcell.KeyUp:
+		switch {
+		case ta.bound(event, config.ActionMenuUp):
 			ta.threadUp()
 			return nil
-		case tcell.KeyDown:
+		case ta.bound(event, config.ActionMenuDown):
 			ta.threadDown()
 			return nil
-		case tcell.KeyEnter:
+		case ta.bound(event, config.ActionSelect):
 			ta.selectThread(ta.threadIndex)
 			return nil
-		case tcell.KeyRune:
+		case ta.bound(event, config.ActionSplitHorizontal):
+			ta.splitThreadList(tview.FlexRow)
+			return nil
+		case ta.bound(event, config.ActionSplitVertical):
+			ta.splitThreadList(tview.FlexColumn)
+			return nil
+		}
+		if event.Key() == tcell.KeyRune {
 			switch event.Rune() {
-			case 'k', 'K':
-				ta.threadUp()
+			case '+', '=':
+				ta.adjustThreadLimit(1)
 				return nil
-			case 'j', 'J':
-				ta.threadDown()
+			case 'm', 'M':
+				ta.openSelectedThreadMedia()
+				return nil
+			case 'o', 'O':
+				if ta.threadIndex < len(ta.threadsData) {
+					ta.openInBrowser("https://reddit.com" + ta.threadsData[ta.threadIndex].Permalink)
+				}
+				return nil
+			case '-':
+				ta.adjustThreadLimit(-1)
+				return nil
+			case 'e', 'E':
+				if ta.threadIndex < len(ta.threadsData) {
+					ta.editNote(&ta.threadsData[ta.threadIndex])
+				}
 				return nil
 			}
 		}
 	}
 
-	switch event.Key() {
-	case tcell.KeyEscape:
+	switch {
+	case ta.bound(event, config.ActionQuit):
+		ta.app.Stop()
+		return nil
+	case ta.bound(event, config.ActionBack):
 		switch pageName {
-		case "threads":
-			ta.showMenu()
-			return nil
-		case "comments":
-			ta.stopAutoRefresh()
-			ta.showThreads()
+		case "threads", "comments":
+			ta.navBack()
 			return nil
 		}
+	case pageName == "comments" && ta.bound(event, config.ActionRefresh):
+		ta.refreshComments()
+		return nil
+	case pageName == "comments" && ta.bound(event, config.ActionFilter):
+		ta.showFilter()
+		return nil
+	case pageName == "comments" && !ta.splitMode && ta.bound(event, config.ActionSplitHorizontal):
+		ta.splitView(tview.FlexRow) // Horizontal split (top/bottom)
+		return nil
+	case pageName == "comments" && !ta.splitMode && ta.bound(event, config.ActionSplitVertical):
+		ta.splitView(tview.FlexColumn) // Vertical split (side by side)
+		return nil
+	}
+
+	switch event.Key() {
 	case tcell.KeyRune:
 		switch event.Rune() {
-		case 'q', 'Q':
-			ta.app.Stop()
-			return nil
-		case 'r', 'R':
+		case 'R':
+			if pageName == "comments" && !ta.splitMode {
+				ta.hardReload()
+				return nil
+			}
 			if pageName == "comments" {
 				ta.refreshComments()
 				return nil
 			}
-		case '/':
-			if pageName == "comments" {
-				ta.showFilter()
+		case 'J':
+			if pageName == "comments" && !ta.splitMode {
+				ta.moveCursor(1)
+				return nil
+			}
+		case 'K':
+			if pageName == "comments" && !ta.splitMode {
+				ta.moveCursor(-1)
+				return nil
+			}
+		case 'u':
+			if pageName == "comments" && !ta.splitMode {
+				ta.jumpToParent()
+				return nil
+			}
+		case 'U':
+			if pageName == "comments" && !ta.splitMode {
+				ta.jumpBack()
+				return nil
+			}
+		case 'e', 'E':
+			if pageName == "comments" && !ta.splitMode {
+				ta.editNote(ta.currentThread)
+				return nil
+			}
+		case 'c':
+			if pageName == "comments" && !ta.splitMode {
+				ta.clearNewMarkers()
+				return nil
+			}
+		case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			if pageName == "comments" && !ta.splitMode {
+				ta.switchRecent(int(event.Rune() - '0'))
+				return nil
+			}
+		case 'o', 'O':
+			if pageName == "comments" && !ta.splitMode {
+				ta.openSelectedInBrowser()
+				return nil
+			}
+		case ' ':
+			if pageName == "comments" && !ta.splitMode {
+				ta.toggleCollapse(false)
+				return nil
+			}
+		case 'm':
+			if pageName == "comments" && !ta.splitMode {
+				ta.loadMoreSelected()
+				return nil
+			}
+		case '@':
+			if pageName == "comments" && !ta.splitMode {
+				ta.nextMention()
+				return nil
+			}
+		case 'b':
+			if pageName == "comments" && !ta.splitMode {
+				ta.toggleCompactHeader()
+				return nil
+			}
+		case 'C':
+			if pageName == "comments" && !ta.splitMode {
+				ta.toggleSinceOpen()
+				return nil
+			}
+		case 'a', 'A':
+			if pageName == "comments" && !ta.splitMode {
+				ta.showAuthorPicker()
+				return nil
+			}
+		case 'i', 'I':
+			if pageName == "comments" && !ta.splitMode {
+				ta.toggleSelfText()
+				return nil
+			}
+		case 'l', 'L':
+			if pageName == "comments" && !ta.splitMode {
+				ta.showLinkPicker()
+				return nil
+			}
+		case 'M':
+			if pageName == "comments" && !ta.splitMode {
+				ta.openMedia()
+				return nil
+			}
+		case 'D':
+			if pageName == "comments" && !ta.splitMode {
+				ta.dumpRawJSON()
+				return nil
+			}
+		case 'y':
+			if pageName == "comments" && !ta.splitMode {
+				ta.copySelected()
+				return nil
+			}
+		case 'Y':
+			if pageName == "comments" && !ta.splitMode {
+				ta.copyCommentQuote()
+				return nil
+			}
+		case 'X':
+			if pageName == "comments" && !ta.splitMode {
+				ta.copyThreadMarkdown()
+				return nil
+			}
+		case 'P':
+			if pageName == "comments" && !ta.splitMode {
+				ta.shareRecap()
+				return nil
+			}
+		case 'z':
+			if pageName == "comments" && !ta.splitMode {
+				ta.collapseAll()
+				return nil
+			}
+		case 'Z':
+			if pageName == "comments" && !ta.splitMode {
+				ta.expandAll()
+				return nil
+			}
+		case 'n':
+			if pageName == "comments" && !ta.splitMode {
+				ta.stepComments(1)
+				return nil
+			}
+		case 'p':
+			if pageName == "comments" && !ta.splitMode {
+				ta.stepComments(-1)
+				return nil
+			}
+		case 'N':
+			if pageName == "comments" && !ta.splitMode {
+				ta.nextMatch(-1)
+				return nil
+			}
+		case '<':
+			if pageName == "comments" && ta.splitMode {
+				ta.resizeSplit(-1)
+				return nil
+			}
+		case '>':
+			if pageName == "comments" && ta.splitMode {
+				ta.resizeSplit(1)
+				return nil
+			}
+		case 'w':
+			if pageName == "comments" && !ta.splitMode {
+				ta.saveThreadMarkdown()
 				return nil
 			}
-		case 'h', 'H':
+		case 'x':
 			if pageName == "comments" && !ta.splitMode {
-				ta.splitView(tview.FlexRow) // Horizontal split (top/bottom)
+				ta.copyCommentLink()
 				return nil
 			}
-		case 'v', 'V':
+		case 'W':
 			if pageName == "comments" && !ta.splitMode {
-				ta.splitView(tview.FlexColumn) // Vertical split (side by side)
+				ta.saveThreadJSON()
+				return nil
+			}
+		case 'f':
+			if pageName == "comments" {
+				ta.toggleRefreshPause()
+				return nil
+			}
+		case 'B':
+			if pageName == "comments" && ta.splitMode {
+				ta.toggleBroadcast()
+				return nil
+			}
+		case 's', 'S':
+			if pageName == "comments" {
+				ta.cycleSort()
+				return nil
+			}
+		case '#':
+			if pageName == "comments" {
+				ta.toggleScores()
+				return nil
+			}
+		case 'd':
+			if pageName == "comments" {
+				ta.cycleTimeDisplay()
 				return nil
 			}
 		case 't', 'T':
 			ta.cycleTheme()
 			return nil
 		}
+	case tcell.KeyCtrlR:
+		ta.retryFailed()
+		return nil
+	case tcell.KeyEnter:
+		if pageName == "comments" && !ta.splitMode {
+			ta.toggleCollapse(true)
+			return nil
+		}
 	case tcell.KeyTab:
 		if pageName == "comments" && ta.splitMode {
 			ta.switchActivePane()
@@ -568,19 +849,32 @@ func (ta *TviewApp) globalKeyHandler(event *tcell.EventKey) *tcell.EventKey {
 	return event
 }
 
+// inputFocused reports whether the user is typing into a text field: the
+// URL page, the comment filter, a status-bar prompt, or any focused
+// InputField.
+func (ta *TviewApp) inputFocused(pageName string) bool {
+	if ta.promptActive || ta.filterActive || pageName == "url" {
+		return true
+	}
+	_, ok := ta.app.GetFocus().(*tview.InputField)
+	return ok
+}
+
 func (ta *TviewApp) showMenu() {
-	ta.updateHeaderWithUpdate("Reddit Stream Console", "Q:Quit  Enter:Select  T:Theme")
+	ta.updateHeaderWithUpdate("Reddit Stream Console", "{quit}:Quit  {select}:Select  O:Quick-open  R:Reload-config  P:Profile  T:Theme")
 	ta.renderMenu()
+	ta.pushNav("menu")
 	ta.pages.SwitchToPage("menu")
+	ta.updateMatchBar()
 	ta.app.SetFocus(ta.menuView)
 }
 
 func (ta *TviewApp) updateHeaderWithUpdate(title, keys string) {
-	ta.header.Clear()
-	fmt.Fprintf(ta.header, " [::b]%s", title)
+	ta.writeHeader(title)
 
 	ta.statusBar.Clear()
 	leftPart := ta.formatKeys(keys)
+	keys = ta.keyHelp(keys)
 
 	if ta.latestVersion != "" {
 		_, _, width, _ := ta.statusBar.GetInnerRect()
@@ -599,22 +893,22 @@ func (ta *TviewApp) updateHeaderWithUpdate(title, keys string) {
 func (ta *TviewApp) showThreads() {
 	title := "Threads"
 	if ta.currentMenu != nil {
-		title = ta.currentMenu.Title
+		title = fmt.Sprintf("%s [%s](limit %d)[-]", ta.currentMenu.Title, ta.theme.Muted.Hex, threadLimit(*ta.currentMenu))
 	}
-	ta.updateHeader(title, "Q:Quit  Enter:Open  T:Theme  Esc:Back")
+	ta.updateHeader(title, "{quit}:Quit  {select}:Open  O:Browser  M:Media  +/-:Limit  E:Note  {split_horizontal}/{split_vertical}:Split  T:Theme  {back}:Back")
 	ta.renderThreadList()
+	ta.pushNav("threads")
 	ta.pages.SwitchToPage("threads")
+	ta.updateMatchBar()
 	ta.app.SetFocus(ta.threadView)
 }
 
 func (ta *TviewApp) showComments() {
-	title := "Comments"
-	if ta.currentThread != nil {
-		title = ta.currentThread.Title
-	}
-	ta.updateHeader(title, "Q:Quit  R:Refresh  /:Filter  H/V:Split  T:Theme  Esc:Back")
+	ta.updateHeader(ta.threadTitle(), commentsKeys)
+	ta.pushNav("comments")
 	ta.pages.SwitchToPage("comments")
 	ta.app.SetFocus(ta.commentsView)
+	ta.updateMatchBar()
 }
 
 func (ta *TviewApp) showURLInput() {
@@ -622,15 +916,14 @@ func (ta *TviewApp) showURLInput() {
 	ta.urlInput.SetText("")
 	ta.urlInput.SetDoneFunc(func(key tcell.Key) {
 		if key == tcell.KeyEnter {
-			url := ta.urlInput.GetText()
-			if url != "" {
-				ta.loadThreadFromURL(url)
-			}
+			ta.submitURLInput(ta.urlInput.GetText())
 		} else if key == tcell.KeyEscape {
 			ta.showMenu()
 		}
 	})
+	ta.pushNav("url")
 	ta.pages.SwitchToPage("url")
+	ta.updateMatchBar()
 	ta.app.SetFocus(ta.urlInput)
 }
 
@@ -639,13 +932,11 @@ func (ta *TviewApp) showFilter() {
 	ta.filterInput.SetText(ta.commentFilter)
 	ta.filterInput.SetDoneFunc(func(key tcell.Key) {
 		ta.commentFilter = ta.filterInput.GetText()
+		ta.filterSeq++ // drop any pending debounced render
 		ta.hideFilter()
 		ta.renderComments()
 	})
-	ta.filterInput.SetChangedFunc(func(text string) {
-		ta.commentFilter = text
-		ta.renderComments()
-	})
+	ta.filterInput.SetChangedFunc(ta.filterChanged)
 
 	// Add filter to comments page
 	commentsFlex := tview.NewFlex().SetDirection(tview.FlexRow).
@@ -663,9 +954,34 @@ func (ta *TviewApp) hideFilter() {
 	ta.app.SetFocus(ta.commentsView)
 }
 
+// prompt temporarily replaces the status bar with a one-line input field.
+// done is called with the entered text on Enter; Esc cancels silently.
+func (ta *TviewApp) prompt(label, initial string, done func(text string)) {
+	prevFocus := ta.app.GetFocus()
+	input := tview.NewInputField().
+		SetLabel(label).
+		SetText(initial).
+		SetFieldBackgroundColor(ta.theme.InputBg.TCell).
+		SetFieldTextColor(ta.theme.Primary.TCell).
+		SetLabelColor(ta.theme.Accent.TCell)
+	input.SetDoneFunc(func(key tcell.Key) {
+		ta.promptActive = false
+		ta.mainFlex.RemoveItem(input)
+		ta.mainFlex.AddItem(ta.statusBar, 1, 0, false)
+		ta.app.SetFocus(prevFocus)
+		if key == tcell.KeyEnter {
+			done(input.GetText())
+		}
+	})
+
+	ta.promptActive = true
+	ta.mainFlex.RemoveItem(ta.statusBar)
+	ta.mainFlex.AddItem(input, 1, 0, true)
+	ta.app.SetFocus(input)
+}
+
 func (ta *TviewApp) updateHeader(title, keys string) {
-	ta.header.Clear()
-	fmt.Fprintf(ta.header, " [::b]%s", title)
+	ta.writeHeader(title)
 
 	ta.statusBar.Clear()
 	fmt.Fprintf(ta.statusBar, " %s", ta.formatKeys(keys))
@@ -678,7 +994,7 @@ func (ta *TviewApp) setStatus(msg string) {
 
 // formatKeys formats "Q:Quit  R:Refresh" into styled "[Q] Quit  [R] Refresh"
 func (ta *TviewApp) formatKeys(keys string) string {
-	parts := strings.Fields(keys)
+	parts := strings.Fields(ta.keyHelp(keys))
 	var formatted []string
 	for _, part := range parts {
 		if idx := strings.Index(part, ":"); idx != -1 {
@@ -692,8 +1008,11 @@ func (ta *TviewApp) formatKeys(keys string) string {
 	return strings.Join(formatted, "  ")
 }
 
-func (ta *TviewApp) selectMenuItem(idx int) {
+// selectMenuItem fetches the threads for a menu item and shows the list.
+// With quickOpen, a single matching thread is opened directly instead.
+func (ta *TviewApp) selectMenuItem(idx int, quickOpen bool) {
 	if idx < 0 || idx >= len(ta.menuItems) {
+		ta.setStatus(noSelectableItems)
 		return
 	}
 
@@ -715,16 +1034,28 @@ func (ta *TviewApp) selectMenuItem(idx int) {
 		threads, err := ta.fetchThreads(item)
 		ta.app.QueueUpdateDraw(func() {
 			if err != nil {
-				ta.setStatus(fmt.Sprintf("Error: %v", err))
+				ta.loadFailed("load "+item.Title, err, func() { ta.selectMenuItem(idx, quickOpen) })
 				return
 			}
+			ta.loadSucceeded()
 			if len(threads) == 0 {
 				ta.setStatus("No threads found")
 				return
 			}
 			ta.threadsData = threads
 			ta.populateThreadList()
+			if quickOpen && len(threads) == 1 {
+				ta.selectThread(0)
+				return
+			}
+			if item.AutoOpenBusiest {
+				ta.selectThread(busiestThread(threads))
+				return
+			}
 			ta.showThreads()
+			if quickOpen {
+				ta.setStatus(fmt.Sprintf("%d threads found — pick one", len(threads)))
+			}
 		})
 	}()
 }
@@ -734,14 +1065,14 @@ func (ta *TviewApp) fetchThreads(item config.MenuItem) ([]reddit.Thread, error)
 	if maxAge == 0 {
 		maxAge = 24
 	}
-	limit := item.Limit
-	if limit == 0 {
-		limit = 50
+	limit := threadLimit(item)
+	if item.Type == "subreddit" {
+		return ta.client.ListSubreddits(item.Subreddit, limit)
 	}
 
 	query := reddit.ThreadQuery{
 		Type:                item.Type,
-		Subreddit:           item.Subreddit,
+		Subreddits:          item.Subreddit,
 		Flairs:              item.Flair,
 		MaxAgeHours:         maxAge,
 		Limit:               limit,
@@ -762,9 +1093,26 @@ func (ta *TviewApp) selectThread(idx int) {
 		return
 	}
 
-	ta.currentThread = &ta.threadsData[idx]
+	ta.openThread(&ta.threadsData[idx], ta.currentMenu)
+}
+
+// newCommentView returns fresh view state for a thread listed by menu
+// (nil when it was opened by URL), with that item's keyword highlighting.
+func (ta *TviewApp) newCommentView(menu *config.MenuItem) commentViewState {
+	return commentViewState{threadMenu: menu, keywords: ta.keywordPattern(menu)}
+}
+
+// openThread shows thread's comments with fresh view state and starts
+// refreshing it. menu is the item that listed the thread, nil for none.
+func (ta *TviewApp) openThread(thread *reddit.Thread, menu *config.MenuItem) {
+	ta.requests.cancelAll()
+	ta.rememberThread(*thread, menu)
+	ta.currentThread = thread
+	ta.commentSort = ""
 	ta.comments = nil
 	ta.commentFilter = ""
+	ta.refreshPaused = false
+	ta.commentViewState = ta.newCommentView(menu)
 	ta.commentsView.Clear()
 	ta.setStatus("Loading comments...")
 	ta.app.ForceDraw()
@@ -778,17 +1126,26 @@ func (ta *TviewApp) loadThreadFromURL(url string) {
 	ta.setStatus("Loading thread...")
 	ta.app.ForceDraw()
 
+	ta.requests.cancelAll()
+	ctx := ta.requests.context()
 	go func() {
-		thread, err := ta.client.ThreadFromURL(url)
+		thread, err := ta.client.ThreadFromURLContext(ctx, url)
 		ta.app.QueueUpdateDraw(func() {
+			if errors.Is(err, context.Canceled) {
+				return // replaced by another load
+			}
 			if err != nil {
-				ta.setStatus(fmt.Sprintf("Error: %v", err))
 				ta.showMenu()
+				ta.loadFailed("open URL", err, func() { ta.loadThreadFromURL(url) })
 				return
 			}
+			ta.loadSucceeded()
+			ta.rememberThread(thread, nil)
 			ta.currentThread = &thread
+			ta.commentSort = ""
 			ta.comments = nil
 			ta.commentFilter = ""
+			ta.commentViewState = ta.newCommentView(nil)
 			ta.commentsView.Clear()
 			ta.loadComments()
 			ta.showComments()
@@ -802,41 +1159,99 @@ func (ta *TviewApp) loadComments() {
 		return
 	}
 
+	thread := ta.currentThread
+	commentSort := ta.commentSort
+	ctx := ta.requests.context()
 	go func() {
-		comments, title, err := ta.client.FetchComments(ta.currentThread.Permalink)
+		comments, post, err := ta.client.FetchCommentsSortedContext(ctx, thread.Permalink, commentSort)
 		ta.app.QueueUpdateDraw(func() {
+			if thread != ta.currentThread || commentSort != ta.commentSort || errors.Is(err, context.Canceled) {
+				return // navigated or re-sorted while loading
+			}
 			if err != nil {
-				ta.setStatus(fmt.Sprintf("Error: %v", err))
+				ta.loadFailed("load comments", err, ta.loadComments)
 				return
 			}
-			if title != "" {
-				ta.currentThread.Title = title
-				ta.updateHeader(title, "Q:Quit  R:Refresh  /:Filter  H/V:Split  T:Theme  Esc:Back")
+			ta.loadSucceeded()
+			cached := post.Offline
+			comments, post = mergeMore(comments, post, ta.moreLoaded)
+			ta.post = post
+			if post.Title != "" {
+				ta.currentThread.Title = post.Title
+				ta.updateHeader(ta.threadTitle(), commentsKeys)
+			}
+			// Sort comments by time (oldest first, newest at bottom) unless
+			// a ranked sort is selected
+			if commentSort.Chronological() {
+				sort.Slice(comments, func(i, j int) bool {
+					return comments[i].CreatedUTC < comments[j].CreatedUTC
+				})
 			}
-			// Sort comments by time (oldest first, newest at bottom)
-			sort.Slice(comments, func(i, j int) bool {
-				return comments[i].CreatedUTC < comments[j].CreatedUTC
-			})
-			ta.comments = comments
+			firstLoad := ta.comments == nil
+			following := atBottom(ta.commentsView)
+			now := time.Now()
+			mode, ttl := ta.cfg.NewHighlightRetention()
+			row, _ := ta.commentsView.GetScrollOffset()
+			_, _, _, height := ta.commentsView.GetInnerRect()
+			ta.expireNew(mode, ttl, ta.visibleIn(row, height), now)
+			ta.comments = reddit.CapComments(comments, ta.cfg.MaxComments)
+			ta.notifyKeywords(ta.currentThread, ta.trackArrivals(ta.comments, now), ta.keywords)
 			ta.renderComments()
-			// Scroll to bottom
-			ta.commentsView.ScrollToEnd()
+			ta.updateMatchBar()
+
+			// A new thread opens at the configured end. After that, follow
+			// new comments only while the reader is already at the bottom.
+			if _, ok := ta.lineOf(ta.selectedID); ok {
+				ta.scrollToSelected()
+			} else if firstLoad && (ta.cfg.StartAtTop() || !commentSort.Chronological()) {
+				ta.commentsView.ScrollToBeginning()
+			} else if firstLoad || following {
+				ta.commentsView.ScrollToEnd()
+			}
+			if cached != nil {
+				ta.loadOffline("load comments", cached, ta.loadComments)
+			}
 		})
 	}()
 }
 
+// atBottom reports whether the last line of view is currently visible.
+func atBottom(view *tview.TextView) bool {
+	row, _ := view.GetScrollOffset()
+	_, _, _, height := view.GetInnerRect()
+	return row+height >= view.GetWrappedLineCount()
+}
+
 func (ta *TviewApp) refreshComments() {
 	ta.setStatus("Refreshing...")
 	ta.loadComments()
 }
 
+// hardReload discards the loaded comments and all per-thread view state
+// (new markers, collapsed replies, selection, filter) and fetches the
+// thread again as if it had just been opened.
+func (ta *TviewApp) hardReload() {
+	if ta.currentThread == nil {
+		return
+	}
+	ta.requests.cancelAll()
+	ta.comments = nil
+	ta.commentFilter = ""
+	ta.commentViewState = ta.newCommentView(ta.threadMenu)
+	ta.commentsView.Clear()
+	ta.commentsView.ScrollToBeginning()
+	ta.setStatus("Reloading thread...")
+	ta.loadComments()
+}
+
 func (ta *TviewApp) startAutoRefresh() {
 	ta.stopAutoRefresh()
 	ta.refreshEnabled = true
 	ta.stopRefresh = make(chan struct{})
 
+	interval := ta.cfg.RefreshInterval(ta.threadMenu)
 	go func() {
-		ticker := time.NewTicker(10 * time.Second)
+		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 
 		for {
@@ -844,6 +1259,13 @@ func (ta *TviewApp) startAutoRefresh() {
 			case <-ticker.C:
 				if ta.refreshEnabled {
 					ta.app.QueueUpdateDraw(func() {
+						if ta.idle() {
+							ta.pauseForIdle()
+							return
+						}
+						if ta.throttled() || ta.refreshPaused {
+							return
+						}
 						ta.loadComments()
 					})
 				}
@@ -862,30 +1284,111 @@ func (ta *TviewApp) stopAutoRefresh() {
 	}
 }
 
+// toggleScores shows or hides comment scores in every comments view.
+func (ta *TviewApp) toggleScores() {
+	ta.hideScores = !ta.hideScores
+	if ta.splitMode {
+		ta.rebuildSplitLayout()
+	} else {
+		ta.renderComments()
+	}
+	if ta.hideScores {
+		ta.setStatus("Scores hidden")
+	} else {
+		ta.setStatus("Scores shown")
+	}
+}
+
 func (ta *TviewApp) renderComments() {
 	ta.commentsView.Clear()
-	ta.renderCommentsToView(ta.commentsView, ta.comments, ta.commentFilter)
+	ta.renderCommentsToView(ta.commentsView, ta.comments, ta.commentFilter, &ta.commentViewState)
+}
+
+// writeSeparator ends a comment with the configured comment_separator.
+func (ta *TviewApp) writeSeparator(out io.Writer, indent string, width int) {
+	switch strings.ToLower(strings.TrimSpace(ta.cfg.CommentSeparator)) {
+	case "none":
+	case "rule":
+		n := width - len(indent) - 2
+		if n < 10 {
+			n = 10
+		}
+		fmt.Fprintf(out, "%s[%s]%s[-]\n", indent, ta.theme.Subtle.Hex, strings.Repeat("─", n))
+	default:
+		fmt.Fprintln(out)
+	}
+}
+
+// replyConnector returns the marker drawn before a reply's header for the
+// configured indent style. Unknown styles fall back to arrows.
+func replyConnector(style string) string {
+	switch strings.ToLower(strings.TrimSpace(style)) {
+	case "none":
+		return ""
+	case "ascii":
+		return "`-"
+	case "unicode":
+		return "└─"
+	default:
+		return "→"
+	}
 }
 
+// wrapText word-wraps a single line to width. Leading indentation is kept
+// on every wrapped line and list items get a hanging indent, so nested
+// lists stay aligned. Lines indented like code are not reflowed. Words
+// wider than the line (long URLs and the like) are broken at the edge.
 func wrapText(text string, width int) []string {
 	if width <= 0 {
 		return []string{text}
 	}
 
-	var lines []string
-	words := strings.Fields(text)
-	if len(words) == 0 {
+	lead, rest := splitIndent(text)
+	if strings.TrimSpace(rest) == "" {
 		return []string{}
 	}
+	marker := listMarker(rest)
+	if marker == "" && len(lead) >= codeIndent {
+		return hardWrap(strings.TrimRight(lead+rest, " \t"), width)
+	}
 
-	currentLine := words[0]
-	for _, word := range words[1:] {
-		if len(currentLine)+1+len(word) <= width {
+	hang := lead + strings.Repeat(" ", len(marker))
+	words := strings.Fields(rest[len(marker):])
+	if len(words) == 0 {
+		return []string{strings.TrimRight(lead+marker, " ")}
+	}
+
+	var lines []string
+	currentLine := lead + marker
+	currentWidth := uniseg.StringWidth(currentLine)
+	started := false
+	for _, word := range words {
+		wordWidth := uniseg.StringWidth(word)
+		switch {
+		case !started:
+		case currentWidth+1+wordWidth <= width:
 			currentLine += " " + word
-		} else {
+			currentWidth += 1 + wordWidth
+			continue
+		default:
 			lines = append(lines, currentLine)
-			currentLine = word
+			currentLine, currentWidth = hang, len(hang)
+		}
+		started = true
+		if currentWidth+wordWidth <= width {
+			currentLine += word
+			currentWidth += wordWidth
+			continue
 		}
+		room := max(width-currentWidth, 1)
+		chunks := breakWidth(word, room)
+		for _, chunk := range chunks[:len(chunks)-1] {
+			lines = append(lines, currentLine+chunk)
+			currentLine = hang
+		}
+		last := chunks[len(chunks)-1]
+		currentLine += last
+		currentWidth = len(hang) + uniseg.StringWidth(last)
 	}
 	lines = append(lines, currentLine)
 	return lines
@@ -899,9 +1402,17 @@ func (ta *TviewApp) Run() error {
 		ta.setStatus(ta.startupNotice)
 	}
 
+	// Mouse capture stops the terminal's own text selection, so it is
+	// opt-in
+	ta.app.EnableMouse(ta.cfg.Mouse)
+
 	// Check for updates in background
 	go ta.checkForUpdates()
 
+	if ta.cfg.WatchMenuConfig {
+		go ta.watchMenuConfig()
+	}
+
 	return ta.app.Run()
 }
 
@@ -919,6 +1430,7 @@ func (ta *TviewApp) applyTheme(t theme.Theme) {
 
 	ta.header.SetBackgroundColor(t.HeaderBg.TCell)
 	ta.header.SetTextColor(t.HeaderFg.TCell)
+	ta.authBadge.SetBackgroundColor(t.HeaderBg.TCell)
 	ta.statusBar.SetBackgroundColor(t.HeaderBg.TCell)
 	ta.statusBar.SetTextColor(t.HeaderFg.TCell)
 
@@ -965,7 +1477,9 @@ func (ta *TviewApp) cycleTheme() {
 		}
 	}
 	next := names[idx]
-	ta.applyTheme(theme.Get(next))
+	// Overrides were already reported at startup; keep applying the valid ones.
+	t, _ := theme.Override(theme.Get(next), ta.cfg.ThemeColors)
+	ta.applyTheme(t)
 
 	if path, err := config.SaveTheme(next); err != nil {
 		ta.setStatus(fmt.Sprintf("Theme: %s (save failed: %v)", next, err))
@@ -979,7 +1493,7 @@ func (ta *TviewApp) checkForUpdates() {
 		return
 	}
 
-	client := &http.Client{Timeout: 5 * time.Second}
+	client := &http.Client{Timeout: 5 * time.Second, Transport: ta.client.Transport()}
 	resp, err := client.Get("https://api.github.com/repos/fenneh/reddit-stream-console/releases/latest")
 	if err != nil {
 		return
@@ -997,11 +1511,7 @@ func (ta *TviewApp) checkForUpdates() {
 		return
 	}
 
-	// Compare versions (strip 'v' prefix)
-	latest := strings.TrimPrefix(release.TagName, "v")
-	current := strings.TrimPrefix(Version, "v")
-
-	if latest != current && latest > current {
+	if newerVersion(release.TagName, Version) {
 		ta.latestVersion = release.TagName
 		ta.app.QueueUpdateDraw(func() {
 			// Refresh menu footer if on menu page
@@ -1013,20 +1523,33 @@ func (ta *TviewApp) checkForUpdates() {
 	}
 }
 
+// scoreLabel is the score shown in a comment header. Reddit hides the
+// score of fresh comments and reports 0, which would be misleading.
+func scoreLabel(c reddit.Comment) string {
+	if c.ScoreHidden {
+		return "score hidden"
+	}
+	return fmt.Sprintf("%d points", c.Score)
+}
+
 type commentNode struct {
 	comment  reddit.Comment
 	children []*commentNode
 }
 
-func buildCommentTree(comments []reddit.Comment, filterLower string) []*commentNode {
+func buildCommentTree(comments []reddit.Comment, q filterQuery, lowered lowerCache) []*commentNode {
 	nodes := make(map[string]*commentNode, len(comments))
 	order := make([]*commentNode, 0, len(comments))
 
 	for _, c := range comments {
-		if filterLower != "" {
-			author := strings.ToLower(c.Author)
-			body := strings.ToLower(c.Body)
-			if !strings.Contains(author, filterLower) && !strings.Contains(body, filterLower) {
+		if q.lowScore(c) {
+			// Replies of a dropped comment become roots below
+			continue
+		}
+		if q.active() && !lowered.matches(c, q) {
+			// Parents come before their replies, so a kept parent is
+			// already in nodes
+			if _, kept := nodes[c.ParentID]; !q.withReplies || c.ParentID == "" || !kept {
 				continue
 			}
 		}
@@ -1069,11 +1592,15 @@ func (ta *TviewApp) splitView(direction int) {
 	ta.primaryPane = NewCommentPane("primary", ta.theme)
 	ta.primaryPane.thread = ta.currentThread
 	ta.primaryPane.comments = ta.comments
+	ta.primaryPane.post = ta.post
 	ta.primaryPane.commentFilter = ta.commentFilter
+	ta.primaryPane.sort = ta.commentSort
+	ta.primaryPane.commentViewState = ta.newCommentView(ta.threadMenu)
 
 	// Create secondary pane for menu
 	ta.secondaryPane = NewCommentPane("secondary", ta.theme)
 	ta.secondaryPane.showingMenu = true
+	ta.secondaryPane.menuIndex = firstMenuIndex(ta.menuItems)
 
 	// Start auto-refresh for primary pane
 	ta.startAutoRefreshForPane(ta.primaryPane)
@@ -1085,6 +1612,7 @@ func (ta *TviewApp) splitView(direction int) {
 
 	// Rebuild the layout
 	ta.rebuildSplitLayout()
+	ta.updateMatchBar()
 }
 
 func (ta *TviewApp) rebuildSplitLayout() {
@@ -1094,8 +1622,9 @@ func (ta *TviewApp) rebuildSplitLayout() {
 	primaryContent := ta.buildPaneContent(ta.primaryPane)
 	secondaryContent := ta.buildPaneContent(ta.secondaryPane)
 
-	splitFlex.AddItem(primaryContent, 0, 1, ta.activePaneID == "primary")
-	splitFlex.AddItem(secondaryContent, 0, 1, ta.activePaneID == "secondary")
+	share := ta.primaryShare()
+	splitFlex.AddItem(primaryContent, 0, share, ta.activePaneID == "primary")
+	splitFlex.AddItem(secondaryContent, 0, 100-share, ta.activePaneID == "secondary")
 
 	ta.pages.AddPage("comments", splitFlex, true, true)
 	ta.updateSplitHeader()
@@ -1147,26 +1676,33 @@ func (ta *TviewApp) buildPaneContent(pane *CommentPane) *tview.Flex {
 
 		var lines []string
 		for i, thread := range pane.threadsData {
+			tag := ta.subredditTag(pane.currentMenu, thread)
 			if i == pane.threadIndex {
-				lines = append(lines, fmt.Sprintf("[%s::b]→ %s[-:-:-]", ta.theme.Accent.Hex, thread.Title))
+				lines = append(lines, fmt.Sprintf("[%s::b]→ %s[-:-:-]%s", ta.theme.Accent.Hex, thread.Title, tag))
 			} else {
-				lines = append(lines, fmt.Sprintf("[%s]  %s[-]", ta.theme.Secondary.Hex, thread.Title))
+				lines = append(lines, fmt.Sprintf("[%s]  %s[-]%s", ta.theme.Secondary.Hex, thread.Title, tag))
 			}
 		}
 		fmt.Fprint(threadView, strings.Join(lines, "\n"))
 		flex.AddItem(threadView, 0, 1, true)
 	} else {
 		// Show comments
+		pane.view.SetTitle(ta.paneCountTitle(pane))
+		pane.view.SetTitleColor(ta.theme.Secondary.TCell)
 		pane.view.Clear()
-		ta.renderCommentsToView(pane.view, pane.comments, pane.commentFilter)
-		pane.view.ScrollToEnd()
+		ta.renderCommentsToView(pane.view, pane.comments, pane.commentFilter, &pane.commentViewState)
+		if pane.sort.Chronological() {
+			pane.view.ScrollToEnd()
+		} else {
+			pane.view.ScrollToBeginning() // best-first sorts read from the top
+		}
 		flex.AddItem(pane.view, 0, 1, true)
 	}
 
 	return flex
 }
 
-func (ta *TviewApp) renderCommentsToView(view *tview.TextView, comments []reddit.Comment, filter string) {
+func (ta *TviewApp) renderCommentsToView(view *tview.TextView, comments []reddit.Comment, filter string, st *commentViewState) {
 	_, _, width, _ := view.GetInnerRect()
 	if width <= 0 {
 		// Estimate width based on terminal size when view not yet drawn
@@ -1183,30 +1719,88 @@ func (ta *TviewApp) renderCommentsToView(view *tview.TextView, comments []reddit
 		}
 	}
 
-	filterLower := strings.ToLower(strings.TrimSpace(filter))
-	roots := buildCommentTree(comments, filterLower)
+	var dst io.Writer = view
+	if max := ta.cfg.MaxLineWidth; max > 0 && width > max {
+		if ta.cfg.CenterColumn {
+			dst = &marginWriter{w: view, margin: strings.Repeat(" ", (width-max)/2)}
+		}
+		width = max
+	}
+
+	roots := buildCommentTree(st.visibleComments(comments), parseFilterQuery(filter), st.filterMatcher())
+
+	// m only loads more in the single view; split panes just show the count
+	selectHint, moreHint := " — select and press m", " — press m"
+	if view != ta.commentsView {
+		selectHint, moreHint = "", ""
+	}
+
+	out := &lineCounter{w: dst}
+	st.rendered = st.rendered[:0]
+	connector := replyConnector(ta.cfg.IndentStyle)
+	timeMode := normalizeTimeDisplay(ta.cfg.TimeDisplay)
+	now := time.Now()
+	var authors map[string]string
+	if ta.cfg.Username != "" {
+		authors = commentAuthors(comments)
+	}
+	ta.renderSelfText(out, st, width)
 
 	var walk func(nodes []*commentNode, depth int)
 	walk = func(nodes []*commentNode, depth int) {
 		for _, node := range nodes {
-			indent := strings.Repeat("  ", depth)
+			st.rendered = append(st.rendered, renderedComment{id: node.comment.ID, line: out.lines, depth: depth})
+
+			indent := strings.Repeat(" ", depth*ta.cfg.IndentSize())
 			arrow := ""
-			if depth > 0 {
-				arrow = fmt.Sprintf("[%s]→[-] ", ta.theme.Accent.Hex)
+			if depth > 0 && connector != "" {
+				arrow = fmt.Sprintf("[%s]%s[-] ", ta.theme.Accent.Hex, connector)
 			}
 
-			header := fmt.Sprintf("%s%s[%s::b]%s[-:-:-] [%s]•[-] [%s]%d points[-] [%s]•[-] [%s]%s[-]",
+			// The selected comment's author is drawn reversed as the cursor
+			authorAttrs := "b"
+			if node.comment.ID == st.selectedID {
+				authorAttrs = "rb"
+			}
+
+			authorColor := ta.theme.Primary.Hex
+			if ta.cfg.ColorAuthors {
+				authorColor = theme.AuthorColor(node.comment.Author)
+			}
+			// Your own comments stand out with an underlined accent name
+			if ta.isOwnComment(node.comment) {
+				authorColor = ta.theme.Accent.Hex
+				authorAttrs += "u"
+			}
+
+			header := fmt.Sprintf("%s%s[%s::%s]%s[-:-:-] ",
 				indent, arrow,
-				ta.theme.Primary.Hex, node.comment.Author,
-				ta.theme.Subtle.Hex,
-				ta.theme.Secondary.Hex, node.comment.Score,
-				ta.theme.Subtle.Hex,
-				ta.theme.Border.Hex, node.comment.FormattedTime)
-			fmt.Fprintln(view, header)
+				authorColor, authorAttrs, node.comment.Author)
+			if st.isOP(node.comment) {
+				header += fmt.Sprintf("[%s:%s:b] OP [-:-:-] ", ta.theme.HeaderFg.Hex, ta.theme.Accent.Hex)
+			}
+			header += fmt.Sprintf("[%s]•[-] ", ta.theme.Subtle.Hex)
+			if !ta.hideScores {
+				header += fmt.Sprintf("[%s]%s[-] [%s]•[-] ",
+					ta.theme.Secondary.Hex, scoreLabel(node.comment),
+					ta.theme.Subtle.Hex)
+			}
+			header += fmt.Sprintf("[%s]%s[-]", ta.theme.Border.Hex,
+				commentTime(timeMode, node.comment.CreatedUTC, node.comment.FormattedTime, ta.client.Location(), now))
+			if edited := editedLabel(node.comment.Edited, node.comment.EditedUTC, now); edited != "" {
+				header += fmt.Sprintf(" [%s]%s[-]", ta.theme.Muted.Hex, edited)
+			}
+			if st.isNew(node.comment.ID) {
+				header += fmt.Sprintf(" [%s::b][NEW[][-:-:-]", ta.theme.Accent.Hex)
+			}
+			if authors != nil && ta.isMention(node.comment, authors) {
+				header += fmt.Sprintf(" [%s::b]@you[-:-:-]", ta.theme.Secondary.Hex)
+			}
+			fmt.Fprintln(out, header)
 
 			bodyIndent := indent
-			if depth > 0 {
-				bodyIndent = indent + "  "
+			if depth > 0 && connector != "" {
+				bodyIndent = indent + strings.Repeat(" ", tview.TaggedStringWidth(connector)+1)
 			}
 
 			bodyWidth := width - len(bodyIndent) - 2
@@ -1214,25 +1808,34 @@ func (ta *TviewApp) renderCommentsToView(view *tview.TextView, comments []reddit
 				bodyWidth = 20
 			}
 
-			for _, paragraph := range strings.Split(node.comment.Body, "\n") {
-				if strings.TrimSpace(paragraph) == "" {
-					fmt.Fprintln(view)
+			for _, line := range wrapBody(node.comment.Body, bodyWidth) {
+				if line == "" {
+					fmt.Fprintln(out)
 					continue
 				}
-				wrappedLines := wrapText(paragraph, bodyWidth)
-				for _, line := range wrappedLines {
-					fmt.Fprintf(view, "%s%s\n", bodyIndent, line)
-				}
+				fmt.Fprintf(out, "%s%s\n", bodyIndent, highlightKeywords(line, st.keywords, ta.theme.Accent.Hex))
+			}
+			if n := len(node.comment.MoreChildren); n > 0 {
+				fmt.Fprintf(out, "%s[%s]▸ load more (%d %s)%s[-]\n", bodyIndent, ta.theme.Muted.Hex, n, plural(n, "reply", "replies"), selectHint)
+			}
+			collapsed := len(node.children) > 0 && st.isCollapsed(node.comment.ID)
+			if collapsed {
+				n := countReplies(node)
+				fmt.Fprintf(out, "%s[%s]▸ %d %s collapsed[-]\n", bodyIndent, ta.theme.Muted.Hex, n, plural(n, "reply", "replies"))
 			}
-			fmt.Fprintln(view)
+			ta.writeSeparator(out, indent, width)
 
-			if len(node.children) > 0 {
+			if len(node.children) > 0 && !collapsed {
 				walk(node.children, depth+1)
 			}
 		}
 	}
 
 	walk(roots, 0)
+
+	if n := len(st.post.MoreChildren); n > 0 {
+		fmt.Fprintf(out, "[%s]▸ load more (%d %s)%s[-]\n", ta.theme.Muted.Hex, n, plural(n, "comment", "comments"), moreHint)
+	}
 }
 
 func (ta *TviewApp) switchActivePane() {
@@ -1265,15 +1868,43 @@ func (ta *TviewApp) updateSplitHeader() {
 			title = fmt.Sprintf("[2] %s", ta.secondaryPane.thread.Title)
 		}
 	}
+	if pane := ta.getActivePane(); pane != nil && pane.thread != nil && pane.sort != "" {
+		title += fmt.Sprintf(" [%s](%s)[-]", ta.theme.Muted.Hex, pane.sort.Label())
+	}
+	if ta.broadcast {
+		title += fmt.Sprintf(" [%s::b]BROADCAST[-:-:-]", ta.theme.Accent.Hex)
+	}
 
-	ta.header.Clear()
-	fmt.Fprintf(ta.header, " [::b]%s", title)
+	ta.writeHeader(title)
 
 	ta.statusBar.Clear()
-	keys := "Q:Quit  R:Refresh  /:Filter  Tab:Switch  Esc:Close"
+	keys := "{quit}:Quit  {refresh}:Refresh  {filter}:Filter  S:Sort  B:Broadcast  </>:Resize  Tab:Switch  {back}:Close"
 	fmt.Fprintf(ta.statusBar, " %s", ta.formatKeys(keys))
 }
 
+// paneCountTitle returns a pane's border title with its comment count and,
+// when there are any, the number of new comments, e.g. " [1] 412 (+7) ".
+func (ta *TviewApp) paneCountTitle(pane *CommentPane) string {
+	num := 1
+	if pane == ta.secondaryPane {
+		num = 2
+	}
+	newCount := 0
+	for _, c := range pane.comments {
+		if pane.isNew(c.ID) {
+			newCount++
+		}
+	}
+	title := fmt.Sprintf(" [%d[] %d ", num, len(pane.comments))
+	if newCount > 0 {
+		title += fmt.Sprintf("[%s](+%d)[-] ", ta.theme.Accent.Hex, newCount)
+	}
+	if pane.refreshPaused {
+		title += "(paused) "
+	}
+	return title
+}
+
 func (ta *TviewApp) getActivePane() *CommentPane {
 	if ta.activePaneID == "secondary" && ta.secondaryPane != nil {
 		return ta.secondaryPane
@@ -1302,14 +1933,27 @@ func (ta *TviewApp) closeSplitMode() {
 		}
 	}
 
-	// Keep primary pane state as current state
-	if ta.primaryPane != nil && ta.primaryPane.thread != nil {
-		ta.currentThread = ta.primaryPane.thread
-		ta.comments = ta.primaryPane.comments
-		ta.commentFilter = ta.primaryPane.commentFilter
+	// Keep primary pane state as current state. A linked thread list
+	// keeps its selection and hands over the thread shown next to it.
+	keep := ta.primaryPane
+	linked := keep != nil && keep.detail != nil
+	if linked {
+		ta.threadIndex = keep.threadIndex
+		keep = keep.detail
+	}
+	if keep != nil && keep.thread != nil {
+		ta.currentThread = keep.thread
+		ta.comments = keep.comments
+		if linked {
+			ta.commentViewState = keep.commentViewState
+		}
+		ta.post = keep.post
+		ta.commentFilter = keep.commentFilter
+		ta.commentSort = keep.sort
 	}
 
 	ta.splitMode = false
+	ta.broadcast = false
 	ta.primaryPane = nil
 	ta.secondaryPane = nil
 	ta.activePaneID = ""
@@ -1328,36 +1972,12 @@ func (ta *TviewApp) closeSplitMode() {
 }
 
 func (ta *TviewApp) paneMenuUp(pane *CommentPane) {
-	orig := pane.menuIndex
-	for {
-		pane.menuIndex--
-		if pane.menuIndex < 0 {
-			pane.menuIndex = len(ta.menuItems) - 1
-		}
-		if pane.menuIndex == orig {
-			break
-		}
-		if ta.menuItems[pane.menuIndex].Type != "separator" {
-			break
-		}
-	}
+	pane.menuIndex = stepMenuIndex(ta.menuItems, pane.menuIndex, -1)
 	ta.rebuildSplitLayout()
 }
 
 func (ta *TviewApp) paneMenuDown(pane *CommentPane) {
-	orig := pane.menuIndex
-	for {
-		pane.menuIndex++
-		if pane.menuIndex >= len(ta.menuItems) {
-			pane.menuIndex = 0
-		}
-		if pane.menuIndex == orig {
-			break
-		}
-		if ta.menuItems[pane.menuIndex].Type != "separator" {
-			break
-		}
-	}
+	pane.menuIndex = stepMenuIndex(ta.menuItems, pane.menuIndex, 1)
 	ta.rebuildSplitLayout()
 }
 
@@ -1408,6 +2028,7 @@ func (ta *TviewApp) paneThreadUp(pane *CommentPane) {
 	if pane.threadIndex < 0 {
 		pane.threadIndex = len(pane.threadsData) - 1
 	}
+	ta.followThreadSelection(pane)
 	ta.rebuildSplitLayout()
 }
 
@@ -1419,6 +2040,7 @@ func (ta *TviewApp) paneThreadDown(pane *CommentPane) {
 	if pane.threadIndex >= len(pane.threadsData) {
 		pane.threadIndex = 0
 	}
+	ta.followThreadSelection(pane)
 	ta.rebuildSplitLayout()
 }
 
@@ -1427,33 +2049,65 @@ func (ta *TviewApp) paneSelectThread(pane *CommentPane) {
 		return
 	}
 
-	thread := pane.threadsData[pane.threadIndex]
+	// A linked thread list already shows the selection next to it
+	if pane.detail != nil {
+		ta.switchActivePane()
+		return
+	}
+
+	if ta.broadcast {
+		ta.broadcastThread(pane.threadsData[pane.threadIndex], pane.currentMenu)
+		return
+	}
+
+	ta.loadPaneThread(pane, pane.threadsData[pane.threadIndex], pane.currentMenu)
+}
+
+// loadPaneThread shows thread's comments in pane, replacing whatever the
+// pane was showing. menu is the item that listed the thread, nil for none.
+func (ta *TviewApp) loadPaneThread(pane *CommentPane, thread reddit.Thread, menu *config.MenuItem) {
+	pane.requests.cancelAll()
 	pane.thread = &thread
 	pane.comments = nil
 	pane.commentFilter = ""
+	pane.refreshPaused = false
 	pane.showingThreads = false
 	pane.showingMenu = false
+	pane.commentViewState = ta.newCommentView(menu)
 
 	ta.setStatus("Loading comments...")
 	ta.app.ForceDraw()
 
+	current, commentSort := pane.thread, pane.sort
+	ctx := pane.requests.context()
 	go func() {
-		comments, title, err := ta.client.FetchComments(thread.Permalink)
+		comments, post, err := ta.client.FetchCommentsSortedContext(ctx, thread.Permalink, commentSort)
 		ta.app.QueueUpdateDraw(func() {
+			if pane.thread != current || errors.Is(err, context.Canceled) {
+				return // selection moved on while loading
+			}
 			if err != nil {
 				ta.setStatus(fmt.Sprintf("Error: %v", err))
 				return
 			}
-			if title != "" {
-				pane.thread.Title = title
+			cached := post.Offline
+			pane.post = post
+			if post.Title != "" {
+				pane.thread.Title = post.Title
+			}
+			// Sort comments by time unless the pane keeps Reddit's order
+			if pane.sort.Chronological() {
+				sort.Slice(comments, func(i, j int) bool {
+					return comments[i].CreatedUTC < comments[j].CreatedUTC
+				})
 			}
-			// Sort comments by time
-			sort.Slice(comments, func(i, j int) bool {
-				return comments[i].CreatedUTC < comments[j].CreatedUTC
-			})
-			pane.comments = comments
+			pane.comments = reddit.CapComments(comments, ta.cfg.MaxComments)
+			pane.trackArrivals(pane.comments, time.Now())
 			ta.rebuildSplitLayout()
 			ta.startAutoRefreshForPane(pane)
+			if cached != nil {
+				ta.loadOffline("load comments", cached, func() { ta.loadCommentsForPane(pane) })
+			}
 		})
 	}()
 }
@@ -1475,16 +2129,24 @@ func (ta *TviewApp) startAutoRefreshForPane(pane *CommentPane) {
 	pane.refreshEnabled = true
 	pane.stopRefresh = make(chan struct{})
 
+	interval := ta.cfg.RefreshInterval(pane.threadMenu)
 	go func() {
-		ticker := time.NewTicker(10 * time.Second)
+		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 
 		for {
 			select {
 			case <-ticker.C:
-				if pane.refreshEnabled && pane.thread != nil {
-					ta.loadCommentsForPane(pane)
-				}
+				// Pane state and its request scope belong to the UI goroutine
+				ta.app.QueueUpdateDraw(func() {
+					if ta.idle() {
+						ta.pauseForIdle()
+						return
+					}
+					if pane.refreshEnabled && !pane.refreshPaused && pane.thread != nil && !ta.throttled() {
+						ta.loadCommentsForPane(pane)
+					}
+				})
 			case <-pane.stopRefresh:
 				return
 			}
@@ -1497,22 +2159,40 @@ func (ta *TviewApp) loadCommentsForPane(pane *CommentPane) {
 		return
 	}
 
+	thread, commentSort := pane.thread, pane.sort
+	ctx := pane.requests.context()
 	go func() {
-		comments, title, err := ta.client.FetchComments(pane.thread.Permalink)
+		comments, post, err := ta.client.FetchCommentsSortedContext(ctx, thread.Permalink, commentSort)
 		ta.app.QueueUpdateDraw(func() {
+			if thread != pane.thread || commentSort != pane.sort || errors.Is(err, context.Canceled) {
+				return // the pane moved on while loading
+			}
 			if err != nil {
 				return
 			}
-			if title != "" {
-				pane.thread.Title = title
+			cached := post.Offline
+			pane.post = post
+			if post.Title != "" {
+				pane.thread.Title = post.Title
 			}
-			sort.Slice(comments, func(i, j int) bool {
-				return comments[i].CreatedUTC < comments[j].CreatedUTC
-			})
-			pane.comments = comments
+			if pane.sort.Chronological() {
+				sort.Slice(comments, func(i, j int) bool {
+					return comments[i].CreatedUTC < comments[j].CreatedUTC
+				})
+			}
+			now := time.Now()
+			mode, ttl := ta.cfg.NewHighlightRetention()
+			row, _ := pane.view.GetScrollOffset()
+			_, _, _, height := pane.view.GetInnerRect()
+			pane.expireNew(mode, ttl, pane.visibleIn(row, height), now)
+			pane.comments = reddit.CapComments(comments, ta.cfg.MaxComments)
+			ta.notifyKeywords(pane.thread, pane.trackArrivals(pane.comments, now), pane.keywords)
 			if ta.splitMode {
 				ta.rebuildSplitLayout()
 			}
+			if cached != nil {
+				ta.loadOffline("load comments", cached, func() { ta.loadCommentsForPane(pane) })
+			}
 		})
 	}()
 }
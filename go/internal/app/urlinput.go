@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+)
+
+// maxSuggestions caps the autocomplete drop-down of the URL input.
+const maxSuggestions = 8
+
+var subredditName = regexp.MustCompile(`^[A-Za-z0-9_+]{2,}$`)
+
+// parseSubredditInput recognises "r/soccer", "/r/soccer/" or a bare
+// "soccer" typed into the URL input and returns the subreddit name.
+func parseSubredditInput(input string) (string, bool) {
+	s := strings.Trim(strings.TrimSpace(input), "/")
+	if rest, ok := strings.CutPrefix(strings.ToLower(s), "r/"); ok {
+		s = s[len(s)-len(rest):]
+	}
+	if !subredditName.MatchString(s) {
+		return "", false
+	}
+	return s, true
+}
+
+// urlSuggestions returns autocomplete entries for the URL input: recent
+// entries first, then the subreddits from the menu config.
+func (ta *TviewApp) urlSuggestions(text string) []string {
+	needle := strings.ToLower(strings.TrimSpace(text))
+	if needle == "" {
+		return nil
+	}
+
+	candidates := append([]string{}, ta.history...)
+	for _, item := range ta.menuItems {
+		for _, sub := range item.Subreddit {
+			if sub = strings.TrimSpace(sub); sub != "" {
+				candidates = append(candidates, "r/"+sub)
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var entries []string
+	for _, c := range candidates {
+		key := strings.ToLower(c)
+		if seen[key] || key == needle || !strings.Contains(key, needle) {
+			continue
+		}
+		seen[key] = true
+		entries = append(entries, c)
+		if len(entries) == maxSuggestions {
+			break
+		}
+	}
+	return entries
+}
+
+// submitURLInput opens a thread URL or, for a subreddit name, lists the
+// subreddit's newest threads. The entry is remembered for autocomplete.
+func (ta *TviewApp) submitURLInput(input string) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return
+	}
+	if history, err := config.AddHistory(input); err == nil {
+		ta.history = history
+	}
+
+	if name, ok := parseSubredditInput(input); ok {
+		ta.openSubreddit(name)
+		return
+	}
+	ta.loadThreadFromURL(input)
+}
+
+// openSubreddit shows the newest threads of a subreddit in the thread list.
+// Like thread URLs, it is limited to allowed_subreddits.
+func (ta *TviewApp) openSubreddit(name string) {
+	if err := ta.client.CheckSubreddit(name); err != nil {
+		ta.loadFailed("load r/"+name, err, nil)
+		return
+	}
+	ta.setStatus(fmt.Sprintf("Loading r/%s...", name))
+	ta.app.ForceDraw()
+
+	go func() {
+		threads, err := ta.client.ListSubreddit(name, defaultThreadLimit)
+		ta.app.QueueUpdateDraw(func() {
+			if err != nil {
+				ta.loadFailed("load r/"+name, err, func() { ta.openSubreddit(name) })
+				return
+			}
+			ta.loadSucceeded()
+			if len(threads) == 0 {
+				ta.setStatus(fmt.Sprintf("No threads found in r/%s", name))
+				return
+			}
+			ta.currentMenu = &config.MenuItem{Title: "r/" + name, Type: "subreddit", Subreddit: config.StringOrSlice{name}}
+			ta.threadsData = threads
+			ta.populateThreadList()
+			ta.showThreads()
+		})
+	}()
+}
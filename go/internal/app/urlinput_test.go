@@ -0,0 +1,38 @@
+package app
+
+import "testing"
+
+func TestParseSubredditInput(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"r/soccer", "soccer", true},
+		{"/r/soccer/", "soccer", true},
+		{"R/NFL", "NFL", true},
+		{"soccer", "soccer", true},
+		{"soccer+football", "soccer+football", true},
+		{"https://www.reddit.com/r/soccer/comments/abc123/", "", false},
+		{"/r/soccer/comments/abc123/match", "", false},
+		{"r/", "", false},
+		{"", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := parseSubredditInput(tc.in)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("parseSubredditInput(%q) = (%q, %v), want (%q, %v)", tc.in, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestURLSuggestions(t *testing.T) {
+	ta := &TviewApp{history: []string{"r/soccer", "https://redd.it/abc"}}
+	got := ta.urlSuggestions("SOC")
+	if len(got) != 1 || got[0] != "r/soccer" {
+		t.Errorf("urlSuggestions = %q, want [r/soccer]", got)
+	}
+	if got := ta.urlSuggestions(""); got != nil {
+		t.Errorf("empty input should not suggest, got %q", got)
+	}
+}
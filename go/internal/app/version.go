@@ -0,0 +1,86 @@
+package app
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed "vMAJOR.MINOR.PATCH[-pre]" release tag.
+type semver struct {
+	core [3]int
+	pre  string // pre-release suffix, empty for a release
+}
+
+// parseSemver parses a version tag, ignoring a leading "v" and any
+// "+build" metadata. Missing minor or patch numbers count as 0.
+func parseSemver(s string) (semver, bool) {
+	var v semver
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, v.pre, _ = strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) > len(v.core) {
+		return semver{}, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		v.core[i] = n
+	}
+	return v, true
+}
+
+// compare returns -1, 0 or 1 as v is older than, the same as, or newer
+// than o. A pre-release is older than the release it precedes.
+func (v semver) compare(o semver) int {
+	for i := range v.core {
+		if v.core[i] != o.core[i] {
+			return cmp.Compare(v.core[i], o.core[i])
+		}
+	}
+	switch {
+	case v.pre == o.pre:
+		return 0
+	case v.pre == "":
+		return 1
+	case o.pre == "":
+		return -1
+	}
+	return comparePre(v.pre, o.pre)
+}
+
+// comparePre orders pre-release suffixes by their dot-separated fields,
+// numerically where both fields are numbers: rc2 < rc10 needs "rc.2".
+func comparePre(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return cmp.Compare(an, bn)
+			}
+		case as[i] != bs[i]:
+			return strings.Compare(as[i], bs[i])
+		}
+	}
+	return cmp.Compare(len(as), len(bs))
+}
+
+// newerVersion reports whether latest is a newer release than current.
+// Tags that don't parse are never reported as updates.
+func newerVersion(latest, current string) bool {
+	l, ok := parseSemver(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseSemver(current)
+	if !ok {
+		return false
+	}
+	return l.compare(c) > 0
+}
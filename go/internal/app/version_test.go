@@ -0,0 +1,28 @@
+package app
+
+import "testing"
+
+func TestNewerVersion(t *testing.T) {
+	cases := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v0.10.0", "v0.9.0", true},
+		{"v0.9.0", "v0.10.0", false},
+		{"v0.10.0", "0.2.0", true},
+		{"v1.2.0", "v1.2.0", false},
+		{"v1.2.0", "v1.2.0-rc1", true},
+		{"v1.2.0-rc1", "v1.2.0", false},
+		{"v1.2.0-rc.10", "v1.2.0-rc.2", true},
+		{"v1.2.1-rc1", "v1.2.0", true},
+		{"v2", "v1.9.9", true},
+		{"v1.2.0+build5", "v1.2.0", false},
+		{"nightly", "v1.0.0", false},
+		{"v1.0.0", "dev", false},
+	}
+	for _, tc := range cases {
+		if got := newerVersion(tc.latest, tc.current); got != tc.want {
+			t.Errorf("newerVersion(%q, %q) = %v, want %v", tc.latest, tc.current, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/fenneh/reddit-stream-console/internal/config"
+)
+
+// ShowWelcome opens the first-run screen in place of the menu. It explains
+// the configured menu, the main keys and how to customise the menu.
+func (ta *TviewApp) ShowWelcome() {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetWordWrap(true)
+	view.SetBackgroundColor(tcell.ColorDefault)
+	view.SetBorder(true)
+	view.SetBorderColor(ta.theme.Border.TCell)
+	view.SetBorderPadding(1, 1, 2, 2)
+	view.SetTitle(" Welcome ")
+	view.SetTitleColor(ta.theme.Primary.TCell)
+	fmt.Fprint(view, ta.welcomeText())
+
+	ta.pages.AddPage("welcome", view, true, true)
+	ta.updateHeaderWithUpdate("Reddit Stream Console", "{quit}:Quit  Enter:Continue  W:Write-config")
+	ta.app.SetFocus(view)
+}
+
+func (ta *TviewApp) welcomeText() string {
+	accent, muted := ta.theme.Accent.Hex, ta.theme.Muted.Hex
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "[%s::b]Follow live Reddit threads from your terminal.[-:-:-]\n\n", accent)
+	b.WriteString("Pick an entry from the menu to list matching threads, then open one to stream its comments. New comments are marked as they arrive.\n\n")
+
+	fmt.Fprintf(&b, "[%s::b]Your menu[-:-:-]\n", accent)
+	for _, item := range ta.menuItems {
+		if !selectable(item) {
+			continue
+		}
+		fmt.Fprintf(&b, "  • %s", tview.Escape(item.Title))
+		if item.Description != "" {
+			fmt.Fprintf(&b, " [%s]— %s[-]", muted, tview.Escape(item.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "\n[%s::b]Keys[-:-:-]\n", accent)
+	for _, k := range [][2]string{
+		{"↑/↓ j/k", "Move through menus and thread lists"},
+		{"Enter", "Select / collapse a comment's replies"},
+		{"/", "Filter comments"},
+		{"r", "Refresh comments"},
+		{"h/v", "Split the screen to watch two threads"},
+		{"t", "Cycle colour themes"},
+		{"Esc", "Go back"},
+		{"q", "Quit"},
+	} {
+		fmt.Fprintf(&b, "  [%s]%-8s[-] %s\n", accent, k[0], k[1])
+	}
+
+	fmt.Fprintf(&b, "\n[%s::b]Custom subreddits[-:-:-]\n", accent)
+	fmt.Fprintf(&b, "The menu is read from %s, using built-in defaults until that file exists. Press [%s]W[-] to write the defaults there, then add an entry per subreddit with its title, subreddit, flair and title filters. Check your edits with [%s]reddit-stream-console validate[-].\n\n",
+		tview.Escape(ta.menuConfigTarget()), accent, accent)
+	fmt.Fprintf(&b, "[%s]Press Enter to continue — this screen is only shown once.[-]\n", muted)
+	return b.String()
+}
+
+// menuConfigTarget is where WriteDefaultMenuConfig puts the menu file.
+func (ta *TviewApp) menuConfigTarget() string {
+	if dir := config.DataDir(); dir != "" {
+		return filepath.Join(dir, "config", "menu_config.json")
+	}
+	return "config/menu_config.json"
+}
+
+// writeDefaultMenuConfig saves the built-in menu so the user can edit it.
+func (ta *TviewApp) writeDefaultMenuConfig() {
+	path, err := config.WriteDefaultMenuConfig()
+	if err != nil {
+		ta.setStatus(fmt.Sprintf("Config not written: %v", err))
+		return
+	}
+	ta.setStatus(fmt.Sprintf("Default menu written to %s", path))
+}
+
+// closeWelcome dismisses the first-run screen for good and shows the menu.
+func (ta *TviewApp) closeWelcome() {
+	_ = config.MarkSetUp()
+	ta.pages.RemovePage("welcome")
+	ta.showMenu()
+}
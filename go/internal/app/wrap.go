@@ -0,0 +1,111 @@
+package app
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// codeIndent is the leading indentation (in spaces) that marks a markdown
+// code line, which is shown verbatim rather than reflowed.
+const codeIndent = 4
+
+// wrapBody wraps a multi-line comment body to width. Blank lines come back
+// as "". Fenced code blocks (```) and indented code lines keep their
+// spacing and are only broken when longer than width. Markdown tables are
+// laid out as aligned columns.
+func wrapBody(body string, width int) []string {
+	var out []string
+	inFence := false
+	lines := strings.Split(body, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.ReplaceAll(lines[i], "\t", strings.Repeat(" ", codeIndent))
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			out = append(out, hardWrap(strings.TrimRight(line, " "), width)...)
+			continue
+		}
+		if inFence {
+			if strings.TrimSpace(line) == "" {
+				out = append(out, "")
+				continue
+			}
+			out = append(out, hardWrap(strings.TrimRight(line, " "), width)...)
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			out = append(out, "")
+			continue
+		}
+		if tableAt(lines, i) {
+			end := i + 2
+			for end < len(lines) && strings.Contains(lines[end], "|") {
+				end++
+			}
+			out = append(out, renderTable(lines[i], lines[i+1], lines[i+2:end], width)...)
+			i = end - 1
+			continue
+		}
+		out = append(out, wrapText(line, width)...)
+	}
+	return out
+}
+
+// splitIndent separates a line's leading whitespace from the rest, with
+// tabs expanded to codeIndent spaces.
+func splitIndent(line string) (string, string) {
+	rest := strings.TrimLeft(line, " \t")
+	lead := line[:len(line)-len(rest)]
+	return strings.ReplaceAll(lead, "\t", strings.Repeat(" ", codeIndent)), rest
+}
+
+// listMarker returns the bullet or number prefix of a list item, including
+// the space after it ("- ", "12. "), or "" if s is not a list item.
+func listMarker(s string) string {
+	for _, bullet := range []string{"- ", "* ", "+ ", "• "} {
+		if strings.HasPrefix(s, bullet) {
+			return bullet
+		}
+	}
+	digits := 0
+	for digits < len(s) && digits < 9 && unicode.IsDigit(rune(s[digits])) {
+		digits++
+	}
+	if digits > 0 && digits+1 < len(s) && (s[digits] == '.' || s[digits] == ')') && s[digits+1] == ' ' {
+		return s[:digits+2]
+	}
+	return ""
+}
+
+// hardWrap breaks line into chunks of at most width columns without
+// touching its whitespace.
+func hardWrap(line string, width int) []string {
+	if width <= 0 || uniseg.StringWidth(line) <= width {
+		return []string{line}
+	}
+	return breakWidth(line, width)
+}
+
+// breakWidth cuts s into chunks of at most width display columns, never
+// splitting a grapheme cluster. A single cluster wider than width still
+// gets a chunk of its own.
+func breakWidth(s string, width int) []string {
+	var out []string
+	var chunk strings.Builder
+	chunkWidth := 0
+	state := -1
+	for len(s) > 0 {
+		var cluster string
+		var w int
+		cluster, s, w, state = uniseg.FirstGraphemeClusterInString(s, state)
+		if chunkWidth > 0 && chunkWidth+w > width {
+			out = append(out, chunk.String())
+			chunk.Reset()
+			chunkWidth = 0
+		}
+		chunk.WriteString(cluster)
+		chunkWidth += w
+	}
+	return append(out, chunk.String())
+}
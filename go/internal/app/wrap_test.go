@@ -0,0 +1,140 @@
+package app
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rivo/uniseg"
+)
+
+func TestWrapTextPlainParagraph(t *testing.T) {
+	got := wrapText("the quick   brown fox jumps", 10)
+	want := []string{"the quick", "brown fox", "jumps"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapText = %q, want %q", got, want)
+	}
+}
+
+func TestWrapTextListHangingIndent(t *testing.T) {
+	got := wrapText("  - first item wraps here", 14)
+	want := []string{"  - first item", "    wraps here"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapText = %q, want %q", got, want)
+	}
+
+	got = wrapText("10. numbered item text", 12)
+	want = []string{"10. numbered", "    item", "    text"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapText = %q, want %q", got, want)
+	}
+}
+
+func TestWrapTextBreaksLongWords(t *testing.T) {
+	long := strings.Repeat("a", 200)
+	got := wrapText("see "+long+" ok", 40)
+	if strings.Join(got, "") != "see"+long+"ok" {
+		t.Errorf("wrapText lost text: %q", got)
+	}
+	for _, line := range got {
+		if w := uniseg.StringWidth(line); w > 40 {
+			t.Errorf("line %q is %d columns wide, want <= 40", line, w)
+		}
+	}
+	if got[0] != "see" || len(got) != 7 {
+		t.Errorf("wrapText = %q, want the token on its own lines", got)
+	}
+
+	got = wrapText("- "+strings.Repeat("界", 10), 10)
+	want := []string{"- 界界界界", "  界界界界", "  界界"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapText wide runes = %q, want %q", got, want)
+	}
+}
+
+func TestWrapTextNestedListNotCode(t *testing.T) {
+	got := wrapText("    * nested", 40)
+	want := []string{"    * nested"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapText = %q, want %q", got, want)
+	}
+}
+
+func TestWrapTextIndentedCodeKeepsSpacing(t *testing.T) {
+	got := wrapText("    x  :=   1", 40)
+	want := []string{"    x  :=   1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapText = %q, want %q", got, want)
+	}
+}
+
+func TestWrapBodyFencedCode(t *testing.T) {
+	body := "look:\n```\nif  a {\n\n    b()\n}\n```\ndone"
+	got := wrapBody(body, 40)
+	want := []string{"look:", "```", "if  a {", "", "    b()", "}", "```", "done"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapBody = %q, want %q", got, want)
+	}
+}
+
+func TestWrapBodyBreaksLongCodeLines(t *testing.T) {
+	got := wrapBody("```\nabcdefghij\n```", 4)
+	want := []string{"```", "abcd", "efgh", "ij", "```"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapBody = %q, want %q", got, want)
+	}
+}
+
+func TestListMarker(t *testing.T) {
+	cases := map[string]string{
+		"- item":   "- ",
+		"* item":   "* ",
+		"3. item":  "3. ",
+		"4) item":  "4) ",
+		"2024 was": "",
+		"-dash":    "",
+		"plain":    "",
+	}
+	for in, want := range cases {
+		if got := listMarker(in); got != want {
+			t.Errorf("listMarker(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWrapBodyTable(t *testing.T) {
+	body := "Stats:\n| Team | Goals |\n|---|--:|\n| Arsenal | 2 |\n| Spurs | 10 |\nafter"
+	got := wrapBody(body, 40)
+	want := []string{
+		"Stats:",
+		"Team    │ Goals",
+		"────────┼──────",
+		"Arsenal │     2",
+		"Spurs   │    10",
+		"after",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapBody = %q, want %q", got, want)
+	}
+}
+
+func TestWrapBodyTableTruncatesCells(t *testing.T) {
+	body := "a | b\n--- | ---\nverylongcell | x"
+	got := wrapBody(body, 10)
+	want := []string{"a      │ b", "───────┼──", "veryl… │ x"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapBody = %q, want %q", got, want)
+	}
+}
+
+func TestWrapBodyTableWideCells(t *testing.T) {
+	body := "| 名前 | n |\n|---|---|\n| 東京 | 1 |\n| Osaka | 2 |"
+	got := wrapBody(body, 40)
+	want := []string{"名前  │ n", "──────┼──", "東京  │ 1", "Osaka │ 2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapBody = %q, want %q", got, want)
+	}
+	if got := truncateWidth("東京タワー", 6); got != "東京…" || uniseg.StringWidth(got) > 6 {
+		t.Errorf("truncateWidth = %q, want 東京…", got)
+	}
+}
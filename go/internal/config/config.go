@@ -2,15 +2,188 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 type AppConfig struct {
 	DebugLogging bool   `json:"debug_logging"`
 	Theme        string `json:"theme"`
+	// ThemeColors overrides individual colours of the selected theme, keyed
+	// by role ("border", "accent", ...) with "#RRGGBB" values.
+	ThemeColors map[string]string `json:"theme_colors"`
+	// Keybindings maps an action ("menu_up", "quit", ...) to the key or
+	// keys that trigger it, replacing that action's defaults.
+	Keybindings map[string]StringOrSlice `json:"keybindings"`
+	// HighlightKeywords are words or phrases ("GOAL", "red card") shown in
+	// bold accent wherever they appear as whole words in a comment.
+	HighlightKeywords []string `json:"highlight_keywords"`
+	// EnableNotifications raises a desktop notification when a refresh
+	// brings in comments mentioning a highlight keyword.
+	EnableNotifications bool `json:"enable_notifications"`
+	// WatchMenuConfig reloads menu_config.json whenever it changes on
+	// disk. R on the menu reloads it by hand either way.
+	WatchMenuConfig bool `json:"watch_menu_config"`
+	// MaxComments caps how many comments are kept per thread. The oldest
+	// root comments (with their replies) are dropped first. 0 = unlimited.
+	MaxComments int `json:"max_comments"`
+	// InitialScroll is where a freshly opened thread starts: "bottom"
+	// (newest comments, the default) or "top" (oldest comments).
+	InitialScroll string `json:"initial_scroll"`
+	// IndentStyle picks the reply connector: "arrows" (default), "ascii",
+	// "unicode" (box-drawing) or "none".
+	IndentStyle string `json:"indent_style"`
+	// IndentWidth is the number of spaces per nesting level. 0 = default (2).
+	IndentWidth int `json:"indent_width"`
+	// Timezone for comment timestamps: "local" (default), "UTC", or an
+	// IANA name such as "Europe/London".
+	Timezone string `json:"timezone"`
+	// CollapseSelfText shows the OP post text as a one-line summary until
+	// expanded.
+	CollapseSelfText bool `json:"collapse_selftext"`
+	// UserAgents, when non-empty, are rotated round-robin per request
+	// instead of using the single REDDIT_USER_AGENT.
+	UserAgents []string `json:"user_agents"`
+	// AllowedSubreddits, when non-empty, limits the URL input (thread links
+	// and r/name) to these subreddits, e.g. for shared or kiosk setups.
+	AllowedSubreddits []string `json:"allowed_subreddits"`
+	// MaxCommentDepth hides replies nested deeper than this many levels
+	// below top-level comments for faster loads. 0 = unlimited.
+	MaxCommentDepth int `json:"max_comment_depth"`
+	// NewHighlight controls how long a comment keeps its "new" marker:
+	// "manual" (until cleared, the default), "refresh" (until the next
+	// refresh), "scroll" (until it has been on screen), or a duration such
+	// as "30s" or a plain number of seconds.
+	NewHighlight string `json:"new_highlight"`
+	// HideScores leaves the "N points" score out of comment headers.
+	HideScores bool `json:"hide_scores"`
+	// MaxLineWidth caps the comment column width on wide terminals.
+	// 0 = use the full terminal width.
+	MaxLineWidth int `json:"max_line_width"`
+	// CenterColumn centres the capped column instead of left-aligning it.
+	CenterColumn bool `json:"center_column"`
+	// CommentSeparator is drawn between comments: "blank" (an empty line,
+	// the default), "rule" (a dim horizontal line) or "none".
+	CommentSeparator string `json:"comment_separator"`
+	// TimeDisplay picks how comment times are shown: "absolute" (the
+	// default), "relative" ("3m ago") or "both" ("15:04 (3m)").
+	TimeDisplay string `json:"time_display"`
+	// MatchHeader pins a line under the header with the score and match
+	// clock parsed from the OP text or stickied comment.
+	MatchHeader bool `json:"match_header"`
+	// PasteEndpoint, when set, enables sharing a thread recap: the recap is
+	// POSTed there as plain text and the service must reply with its URL
+	// (e.g. "https://paste.rs/"). Empty = sharing disabled.
+	PasteEndpoint string `json:"paste_endpoint"`
+	// IdlePauseMinutes pauses auto-refresh after this many minutes without
+	// a keystroke; the next key resumes it. 0 = never pause.
+	IdlePauseMinutes int `json:"idle_pause_minutes"`
+	// MediaViewer is the command that opens thread media, e.g. "mpv" or
+	// "feh --auto-zoom {url}". The URL replaces {url} or is appended.
+	// Empty = open media in the browser.
+	MediaViewer string `json:"media_viewer"`
+	// OfflineCache saves every loaded thread under ~/.reddit-stream-console/
+	// cache and shows the saved copy when a refresh fails.
+	OfflineCache bool `json:"offline_cache"`
+	// ColorAuthors draws each author's name in a colour derived from the
+	// username instead of the theme's primary colour.
+	ColorAuthors bool `json:"color_authors"`
+	// Username is the user's Reddit name. Their own comments are
+	// highlighted, and comments that mention u/Username or reply to them
+	// are marked and reachable with the @ key.
+	Username string `json:"username"`
+	// RefreshIntervalSeconds is how often an open thread is refreshed.
+	// 0 = the default of 10 seconds; values under 2 are raised to 2.
+	RefreshIntervalSeconds int `json:"refresh_interval_seconds"`
+	// ExportDir is where w saves threads as markdown. Empty = the exports
+	// folder under ~/.reddit-stream-console.
+	ExportDir string `json:"export_dir"`
+	// ExportNestedJSON makes the W JSON export nest replies under their
+	// parents instead of listing comments flat with parent IDs.
+	ExportNestedJSON bool `json:"export_nested_json"`
+	// TimeoutSeconds limits each request to Reddit. 0 = 15 seconds; values
+	// under 3 are raised to 3.
+	TimeoutSeconds int `json:"timeout_seconds"`
+	// SearchTimeoutSeconds limits thread searches and subreddit listings,
+	// which can be slower. 0 = TimeoutSeconds.
+	SearchTimeoutSeconds int `json:"search_timeout_seconds"`
+	// ProxyURL sends every request through an http://, https:// or
+	// socks5:// proxy. Empty = use HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+	ProxyURL string `json:"proxy_url"`
+	// Mouse enables the wheel for scrolling and clicks on menu items and
+	// threads. It is off by default because it takes over the terminal's
+	// own text selection.
+	Mouse bool `json:"mouse"`
+}
+
+// Auto-refresh bounds used by RefreshInterval.
+const (
+	DefaultRefreshInterval = 10 * time.Second
+	MinRefreshInterval     = 2 * time.Second
+)
+
+// RefreshInterval returns how often a thread opened from item is refreshed:
+// the item's refresh_interval_seconds when set, else the app-wide value,
+// else DefaultRefreshInterval. item may be nil. The result is never below
+// MinRefreshInterval.
+func (c AppConfig) RefreshInterval(item *MenuItem) time.Duration {
+	secs := c.RefreshIntervalSeconds
+	if item != nil && item.RefreshIntervalSeconds > 0 {
+		secs = item.RefreshIntervalSeconds
+	}
+	if secs <= 0 {
+		return DefaultRefreshInterval
+	}
+	return max(time.Duration(secs)*time.Second, MinRefreshInterval)
+}
+
+// New-comment highlight retention modes returned by NewHighlightRetention.
+const (
+	HighlightManual  = "manual"
+	HighlightRefresh = "refresh"
+	HighlightScroll  = "scroll"
+	HighlightTimed   = "timed"
+)
+
+// NewHighlightRetention parses NewHighlight into a mode and, for
+// HighlightTimed, the duration. Unrecognised values fall back to manual.
+func (c AppConfig) NewHighlightRetention() (string, time.Duration) {
+	value := strings.ToLower(strings.TrimSpace(c.NewHighlight))
+	switch value {
+	case HighlightRefresh, HighlightScroll:
+		return value, 0
+	case "", HighlightManual:
+		return HighlightManual, 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return HighlightTimed, time.Duration(secs) * time.Second
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return HighlightTimed, d
+	}
+	return HighlightManual, 0
+}
+
+// IndentSize returns the configured spaces per nesting level, defaulting to 2.
+func (c AppConfig) IndentSize() int {
+	if c.IndentWidth <= 0 {
+		return 2
+	}
+	return c.IndentWidth
+}
+
+// StartAtTop reports whether threads should open scrolled to the oldest
+// comment instead of the newest.
+func (c AppConfig) StartAtTop() bool {
+	return strings.EqualFold(strings.TrimSpace(c.InitialScroll), "top")
 }
 
 type MenuConfig struct {
@@ -20,17 +193,35 @@ type MenuConfig struct {
 type MenuItem struct {
 	Title               string        `json:"title"`
 	Type                string        `json:"type"`
-	Subreddit           string        `json:"subreddit"`
-	Flair               StringOrSlice `json:"flair"`
-	MaxAgeHours         int           `json:"max_age_hours"`
-	Limit               int           `json:"limit"`
-	TitleMustContain    []string      `json:"title_must_contain"`
-	TitleMustNotContain []string      `json:"title_must_not_contain"`
-	Description         string        `json:"description"`
+	Subreddit           StringOrSlice `json:"subreddit,omitempty"` // one name or a list searched together
+	Flair               StringOrSlice `json:"flair,omitempty"`
+	MaxAgeHours         int           `json:"max_age_hours,omitempty"`
+	Limit               int           `json:"limit,omitempty"`
+	TitleMustContain    []string      `json:"title_must_contain,omitempty"`
+	TitleMustNotContain []string      `json:"title_must_not_contain,omitempty"`
+	Description         string        `json:"description,omitempty"`
+	// AutoOpenBusiest opens the thread with the most comments straight
+	// away instead of listing every match.
+	AutoOpenBusiest bool `json:"auto_open_busiest,omitempty"`
+	// RefreshIntervalSeconds overrides AppConfig.RefreshIntervalSeconds for
+	// threads opened from this item, e.g. to poll a slow subreddit less.
+	RefreshIntervalSeconds int `json:"refresh_interval_seconds,omitempty"`
+	// HighlightKeywords are highlighted in comments of threads opened from
+	// this item, on top of AppConfig.HighlightKeywords.
+	HighlightKeywords []string `json:"highlight_keywords,omitempty"`
 }
 
+// StringOrSlice is a JSON field that may be a single string or a list of
+// strings. A one-element list is written back as a plain string.
 type StringOrSlice []string
 
+func (s StringOrSlice) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 {
+		return json.Marshal(s[0])
+	}
+	return json.Marshal([]string(s))
+}
+
 func (s *StringOrSlice) UnmarshalJSON(data []byte) error {
 	if len(data) == 0 || string(data) == "null" {
 		*s = nil
@@ -59,7 +250,7 @@ func DefaultMenuConfig() MenuConfig {
 			{
 				Title:               "/r/soccer match-threads",
 				Type:                "soccer_match",
-				Subreddit:           "soccer",
+				Subreddit:           StringOrSlice{"soccer"},
 				Flair:               []string{"Match Thread", "match thread"},
 				MaxAgeHours:         6,
 				Limit:               50,
@@ -69,7 +260,7 @@ func DefaultMenuConfig() MenuConfig {
 			{
 				Title:            "/r/soccer post-match-threads",
 				Type:             "soccer_post_match",
-				Subreddit:        "soccer",
+				Subreddit:        StringOrSlice{"soccer"},
 				Flair:            []string{"Post Match Thread", "post match thread"},
 				MaxAgeHours:      12,
 				Limit:            50,
@@ -78,7 +269,7 @@ func DefaultMenuConfig() MenuConfig {
 			{
 				Title:            "/r/fantasypl",
 				Type:             "fpl_rant",
-				Subreddit:        "FantasyPL",
+				Subreddit:        StringOrSlice{"FantasyPL"},
 				Flair:            []string{"GW Rant & Info", "gw rant & info"},
 				MaxAgeHours:      168,
 				Limit:            50,
@@ -87,7 +278,7 @@ func DefaultMenuConfig() MenuConfig {
 			{
 				Title:               "/r/nfl game-threads",
 				Type:                "nfl_game",
-				Subreddit:           "nfl",
+				Subreddit:           StringOrSlice{"nfl"},
 				Flair:               []string{"Game Thread", "game thread"},
 				MaxAgeHours:         12,
 				Limit:               100,
@@ -97,7 +288,7 @@ func DefaultMenuConfig() MenuConfig {
 			{
 				Title:            "/r/nfl post-game-threads",
 				Type:             "nfl_post_game",
-				Subreddit:        "nfl",
+				Subreddit:        StringOrSlice{"nfl"},
 				Flair:            []string{"Game Thread", "game thread"},
 				MaxAgeHours:      12,
 				Limit:            100,
@@ -116,15 +307,17 @@ func DefaultMenuConfig() MenuConfig {
 	}
 }
 
-// LoadMenuConfig loads menu configuration from file, or returns defaults if not found.
+// LoadMenuConfig loads menu configuration from file, or returns defaults if
+// not found. A .yaml or .yml file next to a missing .json one is used
+// instead.
 func LoadMenuConfig(path string) (MenuConfig, error) {
-	data, err := readConfigFile(path)
+	data, resolved, err := readConfigFile(path)
 	if err != nil {
 		// Config file not found - use defaults
 		return DefaultMenuConfig(), nil
 	}
 	var cfg MenuConfig
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	if err := decodeConfig(data, resolved, &cfg); err != nil {
 		return cfg, fmt.Errorf("parse menu config: %w", err)
 	}
 	return cfg, nil
@@ -132,28 +325,98 @@ func LoadMenuConfig(path string) (MenuConfig, error) {
 
 func LoadAppConfig(path string) (AppConfig, error) {
 	var cfg AppConfig
-	data, err := readConfigFile(path)
+	data, resolved, err := readConfigFile(path)
 	if err != nil {
 		return cfg, fmt.Errorf("read app config: %w", err)
 	}
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	if err := decodeConfig(data, resolved, &cfg); err != nil {
 		return cfg, fmt.Errorf("parse app config: %w", err)
 	}
 	return cfg, nil
 }
 
+// isYAML reports whether path names a YAML config file.
+func isYAML(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// decodeConfig decodes a JSON or (by extension) YAML config file into v.
+// YAML goes through JSON so both formats share the json struct tags and
+// custom decoders such as StringOrSlice.
+func decodeConfig(data []byte, path string, v any) error {
+	if !isYAML(path) {
+		if err := json.Unmarshal(data, v); err != nil {
+			return jsonErrorAt(data, err)
+		}
+		return nil
+	}
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc == nil {
+		return nil // empty file
+	}
+	converted, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(converted, v)
+}
+
+// jsonErrorAt prefixes a JSON syntax or type error with the line and
+// column it occurred at, so a typo in a hand-edited file is easy to find.
+func jsonErrorAt(data []byte, err error) error {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return err
+	}
+	line, col := 1, 1
+	for _, b := range data[:min(int(offset), len(data))] {
+		if b == '\n' {
+			line, col = line+1, 1
+		} else {
+			col++
+		}
+	}
+	return fmt.Errorf("line %d, column %d: %w", line, col, err)
+}
+
 // ResolveConfigPath returns the absolute path of the first matching config
-// file found across the search paths, or empty string if none exist.
+// file found across the search paths, or empty string if none exist. A
+// .json name also matches .yaml and .yml files, JSON first.
 func ResolveConfigPath(name string) string {
 	for _, dir := range configSearchPaths() {
-		candidate := filepath.Join(dir, name)
-		if _, err := os.Stat(candidate); err == nil {
-			return candidate
+		for _, candidate := range configCandidates(filepath.Join(dir, name)) {
+			if _, err := os.Stat(candidate); err == nil {
+				return candidate
+			}
 		}
 	}
 	return ""
 }
 
+// configCandidates returns path and, for a .json path, its .yaml and .yml
+// alternatives, in the order they are tried.
+func configCandidates(path string) []string {
+	if strings.ToLower(filepath.Ext(path)) != ".json" {
+		return []string{path}
+	}
+	stem := strings.TrimSuffix(path, filepath.Ext(path))
+	return []string{path, stem + ".yaml", stem + ".yml"}
+}
+
 // SearchPaths returns the directories that are searched for config files,
 // in priority order.
 func SearchPaths() []string {
@@ -168,16 +431,19 @@ func SearchPaths() []string {
 func SaveTheme(name string) (string, error) {
 	target := ResolveConfigPath("config/app_config.json")
 	if target == "" {
-		home := getHomeDir()
-		if home == "" {
+		dataDir := DataDir()
+		if dataDir == "" {
 			return "", fmt.Errorf("could not determine home directory")
 		}
-		dir := filepath.Join(home, ".reddit-stream-console", "config")
+		dir := filepath.Join(dataDir, "config")
 		if err := os.MkdirAll(dir, 0o755); err != nil {
 			return "", err
 		}
 		target = filepath.Join(dir, "app_config.json")
 	}
+	if isYAML(target) {
+		return target, saveThemeYAML(target, name)
+	}
 
 	raw := map[string]any{}
 	if data, err := os.ReadFile(target); err == nil {
@@ -192,6 +458,43 @@ func SaveTheme(name string) (string, error) {
 	return target, os.WriteFile(target, append(data, '\n'), 0o644)
 }
 
+// saveThemeYAML sets the theme key in a YAML app config, keeping the
+// user's comments and key order.
+func saveThemeYAML(path, name string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: top level is not a mapping", path)
+	}
+	set := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "theme" {
+			root.Content[i+1].SetString(name)
+			set = true
+		}
+	}
+	if !set {
+		value := &yaml.Node{}
+		value.SetString(name)
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "theme"}, value)
+	}
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, out, 0o644)
+}
+
 // configSearchPaths returns the list of directories to search for config files.
 // Order: home dir, next to exe, 1 up from exe, 2 up from exe
 func configSearchPaths() []string {
@@ -222,18 +525,23 @@ func getHomeDir() string {
 	return os.Getenv("HOME")
 }
 
-func readConfigFile(path string) ([]byte, error) {
-	if filepath.IsAbs(path) {
-		return os.ReadFile(path)
+// readConfigFile reads the config file at path, or the first match across
+// the search paths for a relative path, and returns its contents and the
+// path actually read.
+func readConfigFile(path string) ([]byte, string, error) {
+	dirs := []string{""}
+	if !filepath.IsAbs(path) {
+		dirs = configSearchPaths()
 	}
 
 	// Search through all candidate directories
-	for _, dir := range configSearchPaths() {
-		candidate := filepath.Join(dir, path)
-		if data, err := os.ReadFile(candidate); err == nil {
-			return data, nil
+	for _, dir := range dirs {
+		for _, candidate := range configCandidates(filepath.Join(dir, path)) {
+			if data, err := os.ReadFile(candidate); err == nil {
+				return data, candidate, nil
+			}
 		}
 	}
 
-	return nil, os.ErrNotExist
+	return nil, "", os.ErrNotExist
 }
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/fenneh/reddit-stream-console/internal/config"
 )
@@ -49,6 +51,33 @@ func TestStringOrSliceEmpty(t *testing.T) {
 	}
 }
 
+func TestStringOrSliceMarshal(t *testing.T) {
+	cases := map[string]config.StringOrSlice{
+		`"soccer"`:              {"soccer"},
+		`["soccer","football"]`: {"soccer", "football"},
+		`null`:                  nil,
+	}
+	for want, s := range cases {
+		got, err := json.Marshal(s)
+		if err != nil || string(got) != want {
+			t.Errorf("Marshal(%v) = %s, %v; want %s", []string(s), got, err, want)
+		}
+	}
+}
+
+func TestMenuItemSubredditList(t *testing.T) {
+	var item config.MenuItem
+	if err := json.Unmarshal([]byte(`{"title":"t","type":"soccer_match","subreddit":["soccer","football"]}`), &item); err != nil {
+		t.Fatal(err)
+	}
+	if len(item.Subreddit) != 2 || item.Subreddit[1] != "football" {
+		t.Errorf("subreddit = %v", []string(item.Subreddit))
+	}
+	if issues := config.ValidateMenuConfig(config.MenuConfig{MenuItems: []config.MenuItem{item}}); len(issues) != 0 {
+		t.Errorf("subreddit list should be valid, got %v", issues)
+	}
+}
+
 func TestDefaultMenuConfigHasItems(t *testing.T) {
 	cfg := config.DefaultMenuConfig()
 	if len(cfg.MenuItems) == 0 {
@@ -101,6 +130,23 @@ func TestLoadMenuConfigInvalidJSON(t *testing.T) {
 	}
 }
 
+func TestLoadMenuConfigErrorPosition(t *testing.T) {
+	cases := map[string]string{
+		"syntax": "{\n  \"menu_items\": [\n    {\"title\": \"A\",}\n  ]\n}",
+		"type":   "{\n  \"menu_items\": [\n    {\"limit\": \"ten\"}\n  ]\n}",
+	}
+	for name, content := range cases {
+		path := filepath.Join(t.TempDir(), "menu_config.json")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := config.LoadMenuConfig(path)
+		if err == nil || !strings.Contains(err.Error(), "line 3,") {
+			t.Errorf("%s: error = %v, want it to point at line 3", name, err)
+		}
+	}
+}
+
 func TestLoadMenuConfigValid(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "menu_config.json")
@@ -144,3 +190,394 @@ func TestLoadAppConfigMissingFile(t *testing.T) {
 		t.Error("expected error for missing app config file")
 	}
 }
+
+func TestAppConfigStartAtTop(t *testing.T) {
+	cases := map[string]bool{
+		"":       false,
+		"bottom": false,
+		"top":    true,
+		" Top ":  true,
+	}
+	for value, want := range cases {
+		cfg := config.AppConfig{InitialScroll: value}
+		if got := cfg.StartAtTop(); got != want {
+			t.Errorf("StartAtTop() with %q = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestSaveNoteRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+
+	if _, err := config.SaveNote("abc123", "  Arsenal vs Spurs, 2-1 "); err != nil {
+		t.Fatalf("SaveNote: %v", err)
+	}
+	if got := config.LoadNotes()["abc123"]; got != "Arsenal vs Spurs, 2-1" {
+		t.Errorf("note = %q, want trimmed note", got)
+	}
+
+	if _, err := config.SaveNote("abc123", ""); err != nil {
+		t.Fatalf("SaveNote: %v", err)
+	}
+	if _, ok := config.LoadNotes()["abc123"]; ok {
+		t.Error("empty note should remove the entry")
+	}
+}
+
+func TestLoadNotesMissingFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+
+	if notes := config.LoadNotes(); len(notes) != 0 {
+		t.Errorf("expected no notes, got %v", notes)
+	}
+}
+
+func TestAddHistoryMostRecentFirst(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+
+	for _, entry := range []string{"r/soccer", "r/nfl", "R/Soccer"} {
+		if _, err := config.AddHistory(entry); err != nil {
+			t.Fatalf("AddHistory: %v", err)
+		}
+	}
+	got := config.LoadHistory()
+	if len(got) != 2 || got[0] != "R/Soccer" || got[1] != "r/nfl" {
+		t.Errorf("history = %q, want [R/Soccer r/nfl]", got)
+	}
+}
+
+func TestAppConfigIndentSize(t *testing.T) {
+	if got := (config.AppConfig{}).IndentSize(); got != 2 {
+		t.Errorf("default IndentSize() = %d, want 2", got)
+	}
+	if got := (config.AppConfig{IndentWidth: 4}).IndentSize(); got != 4 {
+		t.Errorf("IndentSize() = %d, want 4", got)
+	}
+}
+
+func TestAppConfigNewHighlightRetention(t *testing.T) {
+	cases := []struct {
+		value    string
+		wantMode string
+		wantTTL  time.Duration
+	}{
+		{"", config.HighlightManual, 0},
+		{"Refresh", config.HighlightRefresh, 0},
+		{"scroll", config.HighlightScroll, 0},
+		{"30", config.HighlightTimed, 30 * time.Second},
+		{"2m", config.HighlightTimed, 2 * time.Minute},
+		{"-5", config.HighlightManual, 0},
+		{"soon", config.HighlightManual, 0},
+	}
+	for _, tc := range cases {
+		mode, ttl := (config.AppConfig{NewHighlight: tc.value}).NewHighlightRetention()
+		if mode != tc.wantMode || ttl != tc.wantTTL {
+			t.Errorf("NewHighlightRetention() with %q = (%q, %v), want (%q, %v)", tc.value, mode, ttl, tc.wantMode, tc.wantTTL)
+		}
+	}
+}
+
+func TestAppConfigRefreshInterval(t *testing.T) {
+	cases := []struct {
+		app, item int
+		want      time.Duration
+	}{
+		{0, 0, 10 * time.Second},
+		{30, 0, 30 * time.Second},
+		{30, 60, 60 * time.Second},
+		{0, 45, 45 * time.Second},
+		{1, 0, 2 * time.Second},
+		{30, 1, 2 * time.Second},
+		{-5, 0, 10 * time.Second},
+	}
+	for _, tc := range cases {
+		cfg := config.AppConfig{RefreshIntervalSeconds: tc.app}
+		item := &config.MenuItem{RefreshIntervalSeconds: tc.item}
+		if got := cfg.RefreshInterval(item); got != tc.want {
+			t.Errorf("RefreshInterval(app=%d, item=%d) = %v, want %v", tc.app, tc.item, got, tc.want)
+		}
+	}
+	if got := (config.AppConfig{RefreshIntervalSeconds: 20}).RefreshInterval(nil); got != 20*time.Second {
+		t.Errorf("RefreshInterval(nil) = %v, want 20s", got)
+	}
+}
+
+func TestValidateMenuConfigAllSeparators(t *testing.T) {
+	cfg := config.MenuConfig{MenuItems: []config.MenuItem{{Type: "separator"}, {Type: "separator"}}}
+	issues := config.ValidateMenuConfig(cfg)
+	if !issues.HasErrors() {
+		t.Fatalf("expected an error for an all-separator menu, got %v", issues)
+	}
+}
+
+func TestValidateDefaultMenuConfig(t *testing.T) {
+	if issues := config.ValidateMenuConfig(config.DefaultMenuConfig()); len(issues) != 0 {
+		t.Errorf("default config should be valid, got %v", issues)
+	}
+}
+
+func TestValidateMenuConfigReportsIndexAndField(t *testing.T) {
+	cfg := config.MenuConfig{MenuItems: []config.MenuItem{
+		{Title: "ok", Type: "url_input"},
+		{Title: "", Type: "soccer_match", Subreddit: config.StringOrSlice{"soccer"}},
+		{Title: "no sub", Type: "nfl_game", Limit: -1},
+		{Title: "typo", Type: "socer_match", Subreddit: config.StringOrSlice{"soccer"}},
+	}}
+
+	issues := config.ValidateMenuConfig(cfg)
+	want := []string{
+		"menu_items[1].title: must not be empty",
+		`menu_items[2].subreddit: required for type "nfl_game"`,
+		"menu_items[2].limit: must not be negative",
+	}
+	for _, w := range want {
+		found := false
+		for _, issue := range issues {
+			if issue.String() == w && !issue.Warning {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing error %q in %v", w, issues)
+		}
+	}
+
+	var typeWarning bool
+	for _, issue := range issues {
+		if issue.Index == 3 && issue.Field == "type" && issue.Warning {
+			typeWarning = true
+		}
+	}
+	if !typeWarning {
+		t.Error("unknown type should be reported as a warning")
+	}
+	if !issues.HasErrors() || issues.Err() == nil {
+		t.Error("expected config to be invalid")
+	}
+}
+
+func TestValidateMenuConfigEmpty(t *testing.T) {
+	if !config.ValidateMenuConfig(config.MenuConfig{}).HasErrors() {
+		t.Error("empty menu should be an error")
+	}
+}
+
+func TestValidateMenuConfigWarningsOnly(t *testing.T) {
+	cfg := config.MenuConfig{MenuItems: []config.MenuItem{
+		{Title: "custom", Type: "hockey_game", Subreddit: config.StringOrSlice{"hockey"}},
+	}}
+	issues := config.ValidateMenuConfig(cfg)
+	if len(issues) != 1 || issues.HasErrors() || issues.Err() != nil {
+		t.Errorf("expected a single warning, got %v", issues)
+	}
+}
+
+func TestValidateMenuConfigSubredditType(t *testing.T) {
+	cfg := config.MenuConfig{MenuItems: []config.MenuItem{
+		{Title: "r/soccer", Type: "subreddit", Subreddit: config.StringOrSlice{"soccer"}},
+	}}
+	if issues := config.ValidateMenuConfig(cfg); len(issues) != 0 {
+		t.Errorf("subreddit is a built-in type, got %v", issues)
+	}
+}
+
+func TestValidateMenuConfigRefreshInterval(t *testing.T) {
+	cfg := config.MenuConfig{MenuItems: []config.MenuItem{
+		{Title: "fast", Type: "url_input", RefreshIntervalSeconds: 1},
+		{Title: "broken", Type: "url_input", RefreshIntervalSeconds: -1},
+	}}
+	issues := config.ValidateMenuConfig(cfg)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", issues)
+	}
+	if issues[0].Index != 0 || !issues[0].Warning || issues[0].Field != "refresh_interval_seconds" {
+		t.Errorf("interval under the minimum should warn, got %v", issues[0])
+	}
+	if issues[1].Index != 1 || issues[1].Warning {
+		t.Errorf("negative interval should be an error, got %v", issues[1])
+	}
+}
+
+func TestFirstRunAndWriteDefaultMenuConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+
+	if !config.FirstRun() {
+		t.Fatal("FirstRun = false with no data dir")
+	}
+	path, err := config.WriteDefaultMenuConfig()
+	if err != nil {
+		t.Fatalf("WriteDefaultMenuConfig: %v", err)
+	}
+	if config.FirstRun() {
+		t.Error("FirstRun = true after writing the default config")
+	}
+
+	cfg, err := config.LoadMenuConfig(path)
+	if err != nil {
+		t.Fatalf("LoadMenuConfig: %v", err)
+	}
+	if want := config.DefaultMenuConfig(); len(cfg.MenuItems) != len(want.MenuItems) {
+		t.Errorf("round-tripped %d items, want %d", len(cfg.MenuItems), len(want.MenuItems))
+	}
+	if _, err := config.WriteDefaultMenuConfig(); err == nil {
+		t.Error("second WriteDefaultMenuConfig should refuse to overwrite")
+	}
+}
+
+func TestKeyBindings(t *testing.T) {
+	var cfg config.AppConfig
+	data := `{"keybindings": {"menu_down": ["n", "PgDn"], "quit": "ctrl+Q", "jump": "x", "filter": "slash"}}`
+	if err := json.Unmarshal([]byte(data), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	got, err := cfg.KeyBindings()
+	if err == nil {
+		t.Fatal("expected an error for the unknown action and key")
+	}
+	for _, want := range []string{`"jump"`, `"slash"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+	if d := got[config.ActionMenuDown]; len(d) != 2 || d[0] != "n" || d[1] != "pgdn" {
+		t.Errorf("menu_down = %q, want [n pgdn]", d)
+	}
+	if q := got[config.ActionQuit]; len(q) != 1 || q[0] != "ctrl+q" {
+		t.Errorf("quit = %q, want [ctrl+q]", q)
+	}
+	if f := got[config.ActionFilter]; len(f) != 1 || f[0] != "/" {
+		t.Errorf("filter = %q, want the default when every key is invalid", f)
+	}
+	if u := got[config.ActionMenuUp]; len(u) != 3 {
+		t.Errorf("menu_up = %q, want the defaults", u)
+	}
+}
+
+func TestKeyBindingsDefaults(t *testing.T) {
+	got, err := config.AppConfig{}.KeyBindings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(config.KeyActions()) {
+		t.Errorf("got %d actions, want %d", len(got), len(config.KeyActions()))
+	}
+}
+
+func TestLoadYAMLConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	dir := filepath.Join(home, ".reddit-stream-console", "config")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	menu := `# match threads
+menu_items:
+  - title: Soccer
+    type: match_thread
+    subreddit: soccer
+    flair: [Match Thread, Post Match Thread]
+    limit: 10
+`
+	if err := os.WriteFile(filepath.Join(dir, "menu_config.yaml"), []byte(menu), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadMenuConfig("config/menu_config.json")
+	if err != nil {
+		t.Fatalf("LoadMenuConfig: %v", err)
+	}
+	if len(cfg.MenuItems) != 1 {
+		t.Fatalf("got %d items, want 1", len(cfg.MenuItems))
+	}
+	item := cfg.MenuItems[0]
+	if item.Title != "Soccer" || item.Limit != 10 || len(item.Subreddit) != 1 || len(item.Flair) != 2 {
+		t.Errorf("got %+v", item)
+	}
+	if got := config.ResolveConfigPath("config/menu_config.json"); filepath.Base(got) != "menu_config.yaml" {
+		t.Errorf("ResolveConfigPath = %q, want the yaml file", got)
+	}
+
+	// JSON wins when both exist
+	if err := os.WriteFile(filepath.Join(dir, "menu_config.json"), []byte(`{"menu_items":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, _ := config.LoadMenuConfig("config/menu_config.json"); len(cfg.MenuItems) != 0 {
+		t.Error("menu_config.json should take precedence over menu_config.yaml")
+	}
+}
+
+func TestSaveThemeYAML(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	dir := filepath.Join(home, ".reddit-stream-console", "config")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "app_config.yml")
+	if err := os.WriteFile(path, []byte("# my settings\ntheme: nord\nmax_comments: 500\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := config.SaveTheme("dracula"); err != nil || got != path {
+		t.Fatalf("SaveTheme = %q, %v; want %q", got, err, path)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "# my settings") {
+		t.Errorf("comment lost:\n%s", data)
+	}
+	cfg, err := config.LoadAppConfig("config/app_config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Theme != "dracula" || cfg.MaxComments != 500 {
+		t.Errorf("got theme %q, max_comments %d", cfg.Theme, cfg.MaxComments)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	if got := config.Profiles(); len(got) != 0 {
+		t.Errorf("Profiles = %q with no profile dir, want none", got)
+	}
+
+	dir := filepath.Join(home, ".reddit-stream-console", "config", "profiles")
+	if err := os.MkdirAll(filepath.Join(dir, "archive"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"soccer.json", "nfl.yaml", "soccer.yml", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(`{"menu_items":[{"title":"A","type":"url_input"}]}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := config.Profiles()
+	if len(got) != 2 || got[0] != "nfl" || got[1] != "soccer" {
+		t.Errorf("Profiles = %q, want [nfl soccer]", got)
+	}
+
+	if p := config.ProfileMenuPath(""); p != config.MenuConfigPath {
+		t.Errorf("ProfileMenuPath(\"\") = %q, want the default", p)
+	}
+	cfg, err := config.LoadMenuConfig(config.ProfileMenuPath("nfl"))
+	if err != nil || len(cfg.MenuItems) != 1 || cfg.MenuItems[0].Title != "A" {
+		t.Errorf("loading the nfl profile: %+v, %v", cfg.MenuItems, err)
+	}
+}
+
+func TestCheckProfileName(t *testing.T) {
+	for _, name := range []string{"soccer", "nfl-2024", "my.menu"} {
+		if err := config.CheckProfileName(name); err != nil {
+			t.Errorf("CheckProfileName(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"../x", "a/b", `..\x`, ".."} {
+		if err := config.CheckProfileName(name); err == nil {
+			t.Errorf("CheckProfileName(%q) should fail", name)
+		}
+	}
+}
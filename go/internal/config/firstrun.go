@@ -0,0 +1,55 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FirstRun reports whether the app has never been used on this machine:
+// there is no ~/.reddit-stream-console directory and no menu_config.json
+// in any of the search paths.
+func FirstRun() bool {
+	dir := DataDir()
+	if dir == "" {
+		return false
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return false
+	}
+	return ResolveConfigPath("config/menu_config.json") == ""
+}
+
+// MarkSetUp creates ~/.reddit-stream-console so FirstRun reports false
+// from now on.
+func MarkSetUp() error {
+	dir := DataDir()
+	if dir == "" {
+		return fmt.Errorf("could not determine home directory")
+	}
+	return os.MkdirAll(dir, 0o755)
+}
+
+// WriteDefaultMenuConfig writes the built-in menu to
+// ~/.reddit-stream-console/config/menu_config.json as a starting point for
+// customisation. An existing file is left untouched. Returns the path.
+func WriteDefaultMenuConfig() (string, error) {
+	dir := DataDir()
+	if dir == "" {
+		return "", fmt.Errorf("could not determine home directory")
+	}
+	path := filepath.Join(dir, "config", "menu_config.json")
+	if _, err := os.Stat(path); err == nil {
+		return path, fmt.Errorf("%s already exists", path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(DefaultMenuConfig(), "", "    ")
+	if err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, append(data, '\n'), 0o644)
+}
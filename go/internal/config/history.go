@@ -0,0 +1,64 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxHistory caps how many recent URL/subreddit entries are remembered.
+const maxHistory = 50
+
+func historyPath() string {
+	dir := DataDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "history.json")
+}
+
+// LoadHistory returns recently entered URLs and subreddits, most recent
+// first. A missing or unreadable file yields an empty list.
+func LoadHistory() []string {
+	var history []string
+	path := historyPath()
+	if path == "" {
+		return nil
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &history)
+	}
+	return history
+}
+
+// AddHistory records entry as the most recent one, dropping any earlier
+// copy (ignoring case) and the oldest entries beyond the cap. It returns
+// the updated list.
+func AddHistory(entry string) ([]string, error) {
+	entry = strings.TrimSpace(entry)
+	history := LoadHistory()
+	if entry == "" {
+		return history, nil
+	}
+
+	updated := []string{entry}
+	for _, h := range history {
+		if !strings.EqualFold(h, entry) && len(updated) < maxHistory {
+			updated = append(updated, h)
+		}
+	}
+
+	path := historyPath()
+	if path == "" {
+		return updated, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return updated, err
+	}
+	data, err := json.MarshalIndent(updated, "", "    ")
+	if err != nil {
+		return updated, err
+	}
+	return updated, os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Actions that can be rebound through the "keybindings" section of
+// app_config.json.
+const (
+	ActionMenuUp          = "menu_up"
+	ActionMenuDown        = "menu_down"
+	ActionSelect          = "select"
+	ActionBack            = "back"
+	ActionRefresh         = "refresh"
+	ActionFilter          = "filter"
+	ActionSplitHorizontal = "split_horizontal"
+	ActionSplitVertical   = "split_vertical"
+	ActionQuit            = "quit"
+)
+
+// defaultKeybindings are the built-in keys for each action.
+var defaultKeybindings = map[string][]string{
+	ActionMenuUp:          {"k", "K", "up"},
+	ActionMenuDown:        {"j", "J", "down"},
+	ActionSelect:          {"enter"},
+	ActionBack:            {"esc"},
+	ActionRefresh:         {"r"},
+	ActionFilter:          {"/"},
+	ActionSplitHorizontal: {"h", "H"},
+	ActionSplitVertical:   {"v", "V"},
+	ActionQuit:            {"q", "Q"},
+}
+
+// namedKeys are the non-printable keys a binding may use besides a single
+// character and "ctrl+<letter>".
+var namedKeys = map[string]bool{
+	"up": true, "down": true, "left": true, "right": true,
+	"enter": true, "esc": true, "tab": true, "backspace": true, "space": true,
+	"pgup": true, "pgdn": true, "home": true, "end": true,
+}
+
+// KeyActions returns the sorted names of every rebindable action.
+func KeyActions() []string {
+	out := make([]string, 0, len(defaultKeybindings))
+	for action := range defaultKeybindings {
+		out = append(out, action)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// NamedKeys returns the sorted names accepted for non-printable keys.
+func NamedKeys() []string {
+	out := make([]string, 0, len(namedKeys))
+	for name := range namedKeys {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// KeyBindings returns the keys bound to each action: the defaults, with
+// every action listed in Keybindings replaced by the user's keys. Unknown
+// actions and unparseable keys are skipped and reported in the error.
+func (c AppConfig) KeyBindings() (map[string][]string, error) {
+	out := make(map[string][]string, len(defaultKeybindings))
+	for action, keys := range defaultKeybindings {
+		out[action] = keys
+	}
+	var bad []string
+	for _, action := range KeyActions() {
+		keys, ok := c.Keybindings[action]
+		if !ok {
+			continue
+		}
+		var valid []string
+		for _, key := range keys {
+			norm, ok := NormalizeKey(key)
+			if !ok {
+				bad = append(bad, fmt.Sprintf("%s: unknown key %q", action, key))
+				continue
+			}
+			valid = append(valid, norm)
+		}
+		if len(valid) > 0 {
+			out[action] = valid
+		}
+	}
+	for action := range c.Keybindings {
+		if _, ok := defaultKeybindings[action]; !ok {
+			bad = append(bad, fmt.Sprintf("unknown action %q", action))
+		}
+	}
+	if len(bad) > 0 {
+		sort.Strings(bad)
+		return out, fmt.Errorf("keybindings: %s", strings.Join(bad, "; "))
+	}
+	return out, nil
+}
+
+// NormalizeKey returns the canonical form of a key string: a single
+// character as-is (case matters), a named key or "ctrl+<letter>" in lower
+// case. It reports false when key is none of these.
+func NormalizeKey(key string) (string, bool) {
+	if utf8.RuneCountInString(key) == 1 {
+		return key, true
+	}
+	lower := strings.ToLower(strings.TrimSpace(key))
+	if namedKeys[lower] {
+		return lower, true
+	}
+	if letter, ok := strings.CutPrefix(lower, "ctrl+"); ok && len(letter) == 1 && letter[0] >= 'a' && letter[0] <= 'z' {
+		return lower, true
+	}
+	return "", false
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Notes maps reddit thread IDs to short user-written labels, e.g.
+// "Arsenal vs Spurs, 2-1".
+type Notes map[string]string
+
+// DataDir returns ~/.reddit-stream-console, where per-user state such as
+// notes is stored. It returns empty string if the home directory is unknown.
+func DataDir() string {
+	home := getHomeDir()
+	if home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".reddit-stream-console")
+}
+
+func notesPath() string {
+	dir := DataDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "notes.json")
+}
+
+// LoadNotes reads the saved thread notes. A missing or unreadable file
+// yields an empty set rather than an error.
+func LoadNotes() Notes {
+	notes := Notes{}
+	path := notesPath()
+	if path == "" {
+		return notes
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &notes)
+	}
+	return notes
+}
+
+// SaveNote sets the note for threadID and persists all notes. An empty
+// note removes the entry. Returns the path written to.
+func SaveNote(threadID, note string) (string, error) {
+	path := notesPath()
+	if path == "" {
+		return "", fmt.Errorf("could not determine home directory")
+	}
+
+	notes := LoadNotes()
+	if note = strings.TrimSpace(note); note == "" {
+		delete(notes, threadID)
+	} else {
+		notes[threadID] = note
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(notes, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MenuConfigPath is the default menu config, looked up in the search paths.
+const MenuConfigPath = "config/menu_config.json"
+
+// profileDir holds named menu configs, one file per profile, under each
+// config search path.
+const profileDir = "config/profiles"
+
+// ProfileEnv names the environment variable that selects a profile when
+// --profile is not given.
+const ProfileEnv = "REDDIT_STREAM_PROFILE"
+
+// CheckProfileName reports an error for a profile name that would point
+// outside the profiles directory, e.g. "../menu" or "a/b".
+func CheckProfileName(name string) error {
+	if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("invalid profile name %q: must be a file name in %s", name, profileDir)
+	}
+	return nil
+}
+
+// ProfileMenuPath returns the menu config path for a named profile, e.g.
+// "config/profiles/soccer.json" (which also matches soccer.yaml). An empty
+// name is the default menu config.
+func ProfileMenuPath(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return MenuConfigPath
+	}
+	return filepath.ToSlash(filepath.Join(profileDir, name+".json"))
+}
+
+// Profiles returns the sorted names of the profiles found across the
+// config search paths.
+func Profiles() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, dir := range configSearchPaths() {
+		entries, err := os.ReadDir(filepath.Join(dir, profileDir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			switch strings.ToLower(ext) {
+			case ".json", ".yaml", ".yml":
+			default:
+				continue
+			}
+			name := strings.TrimSuffix(entry.Name(), ext)
+			if entry.IsDir() || seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
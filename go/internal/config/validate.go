@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// KnownMenuTypes lists the menu item types the app ships with. Other
+// types still work as subreddit searches but are reported as warnings,
+// since they are usually typos.
+var KnownMenuTypes = []string{
+	"soccer_match",
+	"soccer_post_match",
+	"fpl_rant",
+	"nfl_game",
+	"nfl_post_game",
+	"subreddit",
+	"separator",
+	"url_input",
+}
+
+// Issue is a single problem found in a menu config.
+type Issue struct {
+	Index   int // menu item index, or -1 for the config as a whole
+	Field   string
+	Message string
+	Warning bool // warnings do not make the config invalid
+}
+
+func (i Issue) String() string {
+	if i.Index < 0 {
+		return fmt.Sprintf("menu_items: %s", i.Message)
+	}
+	return fmt.Sprintf("menu_items[%d].%s: %s", i.Index, i.Field, i.Message)
+}
+
+// Issues is the result of ValidateMenuConfig.
+type Issues []Issue
+
+// HasErrors reports whether any issue is an error rather than a warning.
+func (is Issues) HasErrors() bool {
+	for _, i := range is {
+		if !i.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns the errors (not warnings) as a single error, or nil.
+func (is Issues) Err() error {
+	var msgs []string
+	for _, i := range is {
+		if !i.Warning {
+			msgs = append(msgs, i.String())
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid menu config:\n  %s", strings.Join(msgs, "\n  "))
+}
+
+// ValidateMenuConfig checks every menu item and reports problems with the
+// offending item index and field.
+func ValidateMenuConfig(cfg MenuConfig) Issues {
+	var issues Issues
+	if len(cfg.MenuItems) == 0 {
+		return append(issues, Issue{Index: -1, Message: "no menu items defined"})
+	}
+
+	selectable := false
+	for _, item := range cfg.MenuItems {
+		if strings.TrimSpace(item.Type) != "separator" {
+			selectable = true
+		}
+	}
+	if !selectable {
+		issues = append(issues, Issue{Index: -1, Message: "only separators defined, nothing can be selected"})
+	}
+
+	for i, item := range cfg.MenuItems {
+		add := func(field, msg string, warning bool) {
+			issues = append(issues, Issue{Index: i, Field: field, Message: msg, Warning: warning})
+		}
+
+		typ := strings.TrimSpace(item.Type)
+		switch {
+		case typ == "":
+			add("type", "must not be empty", false)
+		case !isKnownMenuType(typ):
+			add("type", fmt.Sprintf("%q is not a built-in type (known: %s)", typ, strings.Join(KnownMenuTypes, ", ")), true)
+		}
+		if typ == "separator" {
+			continue
+		}
+
+		if strings.TrimSpace(item.Title) == "" {
+			add("title", "must not be empty", false)
+		}
+		if typ != "url_input" && typ != "" && strings.TrimSpace(strings.Join(item.Subreddit, "")) == "" {
+			add("subreddit", fmt.Sprintf("required for type %q", typ), false)
+		}
+		if item.MaxAgeHours < 0 {
+			add("max_age_hours", "must not be negative", false)
+		}
+		if item.Limit < 0 {
+			add("limit", "must not be negative", false)
+		}
+		if item.RefreshIntervalSeconds < 0 {
+			add("refresh_interval_seconds", "must not be negative", false)
+		} else if item.RefreshIntervalSeconds > 0 && time.Duration(item.RefreshIntervalSeconds)*time.Second < MinRefreshInterval {
+			add("refresh_interval_seconds", fmt.Sprintf("below the minimum, %v is used", MinRefreshInterval), true)
+		}
+	}
+	return issues
+}
+
+func isKnownMenuType(typ string) bool {
+	for _, known := range KnownMenuTypes {
+		if typ == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package theme
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+)
+
+// AuthorColor returns a stable "#RRGGBB" colour for a username, so the same
+// person is drawn in the same colour everywhere. Hues are spread by a hash
+// of the lowercased name; saturation and lightness are mid-range so the
+// colour reads on both dark and light themes.
+func AuthorColor(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(name)))
+	hue := float64(h.Sum32() % 360)
+	r, g, b := hslToRGB(hue, 0.6, 0.55)
+	return fmt.Sprintf("#%02X%02X%02X", r, g, b)
+}
+
+// hslToRGB converts hue (degrees), saturation and lightness (0-1) to RGB.
+func hslToRGB(h, s, l float64) (uint8, uint8, uint8) {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return uint8(math.Round((r + m) * 255)), uint8(math.Round((g + m) * 255)), uint8(math.Round((b + m) * 255))
+}
@@ -38,14 +38,85 @@ type Theme struct {
 }
 
 func hex(s string) Color {
-	var r, g, b int32
-	if _, err := fmt.Sscanf(strings.TrimPrefix(s, "#"), "%02x%02x%02x", &r, &g, &b); err != nil {
+	c, ok := parseHex(s)
+	if !ok {
 		return Color{TCell: tcell.ColorDefault, Hex: "#000000"}
 	}
+	return c
+}
+
+// parseHex parses "#RRGGBB" (the leading # is optional).
+func parseHex(s string) (Color, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	var r, g, b int32
+	if len(s) != 6 {
+		return Color{}, false
+	}
+	if _, err := fmt.Sscanf(s, "%02x%02x%02x", &r, &g, &b); err != nil {
+		return Color{}, false
+	}
 	return Color{
 		TCell: tcell.NewRGBColor(r, g, b),
 		Hex:   fmt.Sprintf("#%02X%02X%02X", r, g, b),
+	}, true
+}
+
+// roles maps the keys accepted by Override to the Theme field they set.
+var roles = map[string]func(*Theme) *Color{
+	"header_bg":       func(t *Theme) *Color { return &t.HeaderBg },
+	"header_fg":       func(t *Theme) *Color { return &t.HeaderFg },
+	"border":          func(t *Theme) *Color { return &t.Border },
+	"inactive_border": func(t *Theme) *Color { return &t.InactiveBorder },
+	"primary":         func(t *Theme) *Color { return &t.Primary },
+	"accent":          func(t *Theme) *Color { return &t.Accent },
+	"secondary":       func(t *Theme) *Color { return &t.Secondary },
+	"muted":           func(t *Theme) *Color { return &t.Muted },
+	"subtle":          func(t *Theme) *Color { return &t.Subtle },
+	"input_bg":        func(t *Theme) *Color { return &t.InputBg },
+	"placeholder":     func(t *Theme) *Color { return &t.Placeholder },
+}
+
+// Roles returns the sorted colour keys accepted by Override.
+func Roles() []string {
+	out := make([]string, 0, len(roles))
+	for role := range roles {
+		out = append(out, role)
 	}
+	sort.Strings(out)
+	return out
+}
+
+// Override returns t with the colours in colors (role -> "#RRGGBB")
+// replaced. Unknown roles and malformed colours are skipped and reported
+// in the returned error; every valid entry is still applied.
+func Override(t Theme, colors map[string]string) (Theme, error) {
+	var bad []string
+	for _, role := range sortedKeys(colors) {
+		field, ok := roles[strings.ToLower(strings.TrimSpace(role))]
+		if !ok {
+			bad = append(bad, fmt.Sprintf("unknown colour %q", role))
+			continue
+		}
+		c, ok := parseHex(colors[role])
+		if !ok {
+			bad = append(bad, fmt.Sprintf("%s: %q is not a #RRGGBB colour", role, colors[role]))
+			continue
+		}
+		*field(&t) = c
+	}
+	if len(bad) > 0 {
+		return t, fmt.Errorf("theme_colors: %s", strings.Join(bad, "; "))
+	}
+	return t, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
 }
 
 var themes = map[string]Theme{
@@ -58,6 +129,8 @@ var themes = map[string]Theme{
 	"nord":                 nord(),
 	"gruvbox-dark":         gruvboxDark(),
 	"tokyo-night":          tokyoNight(),
+	"solarized-dark":       solarizedDark(),
+	"solarized-light":      solarizedLight(),
 }
 
 // Get returns the named theme, or Default() if name is empty or unknown.
@@ -242,3 +315,37 @@ func tokyoNight() Theme {
 		Placeholder:    hex("#565f89"),
 	}
 }
+
+func solarizedDark() Theme {
+	return Theme{
+		Name:           "solarized-dark",
+		HeaderBg:       hex("#073642"), // base02
+		HeaderFg:       hex("#b58900"), // yellow
+		Border:         hex("#268bd2"), // blue
+		InactiveBorder: hex("#073642"),
+		Primary:        hex("#93a1a1"), // base1
+		Accent:         hex("#b58900"), // yellow
+		Secondary:      hex("#2aa198"), // cyan
+		Muted:          hex("#839496"), // base0
+		Subtle:         hex("#586e75"), // base01
+		InputBg:        hex("#073642"),
+		Placeholder:    hex("#586e75"),
+	}
+}
+
+func solarizedLight() Theme {
+	return Theme{
+		Name:           "solarized-light",
+		HeaderBg:       hex("#eee8d5"), // base2
+		HeaderFg:       hex("#cb4b16"), // orange
+		Border:         hex("#268bd2"), // blue
+		InactiveBorder: hex("#eee8d5"),
+		Primary:        hex("#586e75"), // base01
+		Accent:         hex("#cb4b16"), // orange
+		Secondary:      hex("#2aa198"), // cyan
+		Muted:          hex("#657b83"), // base00
+		Subtle:         hex("#93a1a1"), // base1
+		InputBg:        hex("#eee8d5"),
+		Placeholder:    hex("#93a1a1"),
+	}
+}
@@ -84,7 +84,8 @@ func TestNamesAreSorted(t *testing.T) {
 
 func TestNamesContainsExpected(t *testing.T) {
 	expected := []string{"default", "dracula", "nord", "gruvbox-dark", "tokyo-night",
-		"catppuccin-mocha", "catppuccin-macchiato", "catppuccin-frappe", "catppuccin-latte"}
+		"catppuccin-mocha", "catppuccin-macchiato", "catppuccin-frappe", "catppuccin-latte",
+		"solarized-dark", "solarized-light"}
 	names := theme.Names()
 	set := make(map[string]bool, len(names))
 	for _, n := range names {
@@ -118,6 +119,48 @@ func TestAllThemesHaveHexColors(t *testing.T) {
 	}
 }
 
+func TestOverride(t *testing.T) {
+	base := theme.Get("nord")
+	th, err := theme.Override(base, map[string]string{
+		"border":    "#ff0000",
+		"Header_FG": "00ff00",
+	})
+	if err != nil {
+		t.Fatalf("Override: %v", err)
+	}
+	if th.Name != "nord" {
+		t.Errorf("Name = %q, want nord", th.Name)
+	}
+	if th.Border.Hex != "#FF0000" || th.HeaderFg.Hex != "#00FF00" {
+		t.Errorf("Border = %s, HeaderFg = %s", th.Border.Hex, th.HeaderFg.Hex)
+	}
+	if th.Accent != base.Accent {
+		t.Error("colours not listed should be left alone")
+	}
+}
+
+func TestOverrideReportsBadEntries(t *testing.T) {
+	th, err := theme.Override(theme.Default(), map[string]string{
+		"accent":   "#123456",
+		"border":   "teal",
+		"nonsense": "#ffffff",
+	})
+	if err == nil {
+		t.Fatal("expected an error for the bad entries")
+	}
+	for _, want := range []string{"border", "nonsense"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if th.Accent.Hex != "#123456" {
+		t.Errorf("valid entries should still apply, Accent = %s", th.Accent.Hex)
+	}
+	if th.Border != theme.Default().Border {
+		t.Error("a malformed colour should leave the role unchanged")
+	}
+}
+
 func TestDefaultIsConsistent(t *testing.T) {
 	d1 := theme.Default()
 	d2 := theme.Default()
@@ -125,3 +168,16 @@ func TestDefaultIsConsistent(t *testing.T) {
 		t.Error("Default() should be deterministic")
 	}
 }
+
+func TestAuthorColorStable(t *testing.T) {
+	a := theme.AuthorColor("GoonerFan")
+	if a != theme.AuthorColor("goonerfan") {
+		t.Error("AuthorColor should ignore case")
+	}
+	if len(a) != 7 || a[0] != '#' {
+		t.Errorf("AuthorColor = %q, want #RRGGBB", a)
+	}
+	if a == theme.AuthorColor("SpursFan") && a == theme.AuthorColor("KopFan") {
+		t.Error("expected different names to spread across colours")
+	}
+}
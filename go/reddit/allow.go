@@ -0,0 +1,48 @@
+package reddit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetAllowedSubreddits restricts ThreadFromURL to the given subreddits
+// (case-insensitive, with or without the "r/" prefix); other lookups can
+// be checked with CheckSubreddit. Listings are not filtered, so configured
+// menu items keep working. An empty list allows every subreddit.
+func (c *Client) SetAllowedSubreddits(names []string) {
+	c.allowedSubs = nil
+	for _, name := range names {
+		name = subredditKey(name)
+		if name == "" {
+			continue
+		}
+		if c.allowedSubs == nil {
+			c.allowedSubs = make(map[string]bool)
+		}
+		c.allowedSubs[name] = true
+	}
+}
+
+// CheckSubreddit returns an error wrapping ErrSubredditNotAllowed when an
+// allowlist is set and name is not on it.
+func (c *Client) CheckSubreddit(name string) error {
+	if c.allowedSubs == nil || c.allowedSubs[subredditKey(name)] {
+		return nil
+	}
+	return fmt.Errorf("%w: r/%s", ErrSubredditNotAllowed, strings.Trim(strings.TrimSpace(name), "/"))
+}
+
+func subredditKey(name string) string {
+	name = strings.ToLower(strings.Trim(strings.TrimSpace(name), "/"))
+	return strings.TrimPrefix(name, "r/")
+}
+
+// PermalinkSubreddit returns the subreddit of a permalink such as
+// "/r/soccer/comments/abc123/title", or "".
+func PermalinkSubreddit(permalink string) string {
+	parts := strings.Split(strings.Trim(permalink, "/"), "/")
+	if len(parts) >= 2 && strings.EqualFold(parts[0], "r") {
+		return parts[1]
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+package reddit
+
+// AuthState says whether a Client's requests carry an OAuth token. Without
+// one Reddit applies its stricter anonymous rate limits.
+type AuthState int32
+
+const (
+	AuthAnonymous     AuthState = iota // no credentials configured
+	AuthAuthenticated                  // requests use a valid token
+	AuthExpired                        // the token expired and could not be renewed
+)
+
+func (s AuthState) String() string {
+	switch s {
+	case AuthAuthenticated:
+		return "authenticated"
+	case AuthExpired:
+		return "auth expired"
+	default:
+		return "anonymous"
+	}
+}
+
+// AuthState reports the client's current authentication state. Clients
+// without OAuth credentials are always AuthAnonymous.
+func (c *Client) AuthState() AuthState {
+	return AuthState(c.auth.Load())
+}
+
+// OnAuthChange registers fn to be called whenever the authentication state
+// changes, e.g. when a token is obtained, refreshed or expires. fn may run
+// on a request goroutine.
+func (c *Client) OnAuthChange(fn func(AuthState)) {
+	c.onAuth.Store(&fn)
+}
+
+func (c *Client) setAuthState(s AuthState) {
+	if AuthState(c.auth.Swap(int32(s))) == s {
+		return
+	}
+	if fn := c.onAuth.Load(); fn != nil && *fn != nil {
+		(*fn)(s)
+	}
+}
@@ -0,0 +1,90 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRetries is how often a throttled request is retried before its
+	// error is returned.
+	maxRetries = 3
+	// maxBackoff caps both the exponential backoff and Retry-After.
+	maxBackoff = 30 * time.Second
+)
+
+// throttleState is the backoff shared by every request of a Client, so
+// one throttled request holds back the others instead of letting them
+// pile on.
+type throttleState struct {
+	mu     sync.Mutex
+	until  time.Time
+	notify func(time.Duration)
+}
+
+// Backoff returns how long requests are held back after Reddit answered
+// 429 Too Many Requests or 503 Service Unavailable, or 0 when they are not.
+func (c *Client) Backoff() time.Duration {
+	c.throttle.mu.Lock()
+	defer c.throttle.mu.Unlock()
+	return max(time.Until(c.throttle.until), 0)
+}
+
+// OnBackoff registers fn to be called with the wait whenever a throttled
+// request backs off, e.g. to show "rate limited, retrying in 5s". fn may
+// run on a request goroutine.
+func (c *Client) OnBackoff(fn func(time.Duration)) {
+	c.throttle.mu.Lock()
+	defer c.throttle.mu.Unlock()
+	c.throttle.notify = fn
+}
+
+// throttled reports whether status asks the client to slow down.
+func throttled(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// retryDelay returns the wait before retry attempt (0-based): the
+// Retry-After header in seconds or as an HTTP date when present, otherwise
+// base doubled per attempt. Both are capped at maxBackoff.
+func retryDelay(header string, attempt int, base time.Duration, now time.Time) time.Duration {
+	wait := base << attempt
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		wait = time.Duration(secs) * time.Second
+	} else if at, err := http.ParseTime(header); err == nil {
+		wait = max(at.Sub(now), 0)
+	}
+	return min(wait, maxBackoff)
+}
+
+// backOff holds every request back for wait.
+func (c *Client) backOff(wait time.Duration) {
+	c.throttle.mu.Lock()
+	if until := time.Now().Add(wait); until.After(c.throttle.until) {
+		c.throttle.until = until
+	}
+	notify := c.throttle.notify
+	c.throttle.mu.Unlock()
+	if notify != nil {
+		notify(wait)
+	}
+}
+
+// waitBackoff sleeps until the shared backoff has passed or ctx is done.
+func (c *Client) waitBackoff(ctx context.Context) error {
+	wait := c.Backoff()
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
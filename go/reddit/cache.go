@@ -0,0 +1,185 @@
+package reddit
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OfflineCopy marks a comment listing served from the offline cache (see
+// SetCacheDir) because the live fetch failed. The comments are usable but
+// may be out of date.
+type OfflineCopy struct {
+	FetchedAt time.Time // when the cached copy was fetched
+	Err       error     // why the live fetch failed
+}
+
+// Offline cache limits. A thread is rewritten at most once per
+// cacheWriteInterval, and only when its comments changed; entries older
+// than cacheMaxAge, or beyond the newest cacheMaxEntries, are pruned the
+// first time the cache is written in a session.
+const (
+	cacheWriteInterval = 2 * time.Minute
+	cacheMaxAge        = 7 * 24 * time.Hour
+	cacheMaxEntries    = 200
+)
+
+// cacheState tracks what this session wrote to the offline cache.
+type cacheState struct {
+	mu      sync.Mutex
+	written map[string]cacheWrite // by cache path
+	pruned  bool
+}
+
+type cacheWrite struct {
+	at  time.Time
+	sum [sha1.Size]byte // of the saved post and comments
+}
+
+// cacheEntry is the on-disk form of one cached comment listing.
+type cacheEntry struct {
+	Permalink string    `json:"permalink"`
+	Sort      string    `json:"sort"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Post      Post      `json:"post"`
+	Comments  []Comment `json:"comments"`
+}
+
+// SetCacheDir enables the offline cache: successful comment fetches are
+// saved under dir, and a failed fetch returns the saved copy with
+// Post.Offline set instead of an error. An empty dir disables the cache.
+func (c *Client) SetCacheDir(dir string) {
+	c.cacheDir = dir
+}
+
+func (c *Client) cachePath(permalink string, sort CommentSort) string {
+	if sort == "" {
+		sort = SortNew
+	}
+	sum := sha1.Sum([]byte(permalink + "?sort=" + string(sort)))
+	return filepath.Join(c.cacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// saveCache stores a fetched listing unless the same thread was saved
+// less than cacheWriteInterval ago or is unchanged. Failures are ignored;
+// the cache is best effort.
+func (c *Client) saveCache(permalink string, sort CommentSort, comments []Comment, post Post, now time.Time) {
+	if c.cacheDir == "" {
+		return
+	}
+	content, err := json.Marshal(struct {
+		Post     Post      `json:"post"`
+		Comments []Comment `json:"comments"`
+	}{post, comments})
+	if err != nil {
+		return
+	}
+	path := c.cachePath(permalink, sort)
+	sum := sha1.Sum(content)
+
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	if last, ok := c.cache.written[path]; ok && (last.sum == sum || now.Sub(last.at) < cacheWriteInterval) {
+		return
+	}
+	if !c.cache.pruned {
+		c.cache.pruned = true
+		pruneCache(c.cacheDir, now)
+	}
+
+	data, err := json.Marshal(cacheEntry{
+		Permalink: permalink,
+		Sort:      string(sort),
+		FetchedAt: now,
+		Post:      post,
+		Comments:  comments,
+	})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.cacheDir, 0o755); err != nil {
+		return
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return
+	}
+	if os.Rename(tmp, path) != nil {
+		return
+	}
+	if c.cache.written == nil {
+		c.cache.written = make(map[string]cacheWrite)
+	}
+	c.cache.written[path] = cacheWrite{at: now, sum: sum}
+}
+
+// pruneCache removes cache entries older than cacheMaxAge, then the oldest
+// ones beyond cacheMaxEntries, along with leftover temporary files.
+func pruneCache(dir string, now time.Time) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	type file struct {
+		path string
+		mod  time.Time
+	}
+	var kept []file
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		switch {
+		case strings.HasSuffix(e.Name(), ".tmp"):
+			_ = os.Remove(path)
+		case filepath.Ext(e.Name()) != ".json":
+		case now.Sub(info.ModTime()) > cacheMaxAge:
+			_ = os.Remove(path)
+		default:
+			kept = append(kept, file{path, info.ModTime()})
+		}
+	}
+	if len(kept) <= cacheMaxEntries {
+		return
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].mod.After(kept[j].mod) })
+	for _, f := range kept[cacheMaxEntries:] {
+		_ = os.Remove(f.path)
+	}
+}
+
+func (c *Client) loadCache(permalink string, sort CommentSort) (cacheEntry, bool) {
+	var entry cacheEntry
+	if c.cacheDir == "" {
+		return entry, false
+	}
+	data, err := os.ReadFile(c.cachePath(permalink, sort))
+	if err != nil || json.Unmarshal(data, &entry) != nil {
+		return entry, false
+	}
+	return entry, true
+}
+
+// offlineFallback reports whether a failed fetch should be answered from
+// the cache. Cancellations and answers that say the thread is gone or
+// private are passed through as they are.
+func offlineFallback(err error) bool {
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, ErrNotFound),
+		errors.Is(err, ErrForbidden),
+		errors.Is(err, ErrInvalidURL):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,724 @@
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Client fetches threads and comments from Reddit's public JSON endpoints.
+// A Client is safe for concurrent use once configured; the Set* methods
+// should be called before the first request.
+type Client struct {
+	httpClient *http.Client
+	userAgent  string
+	location   *time.Location // nil means the local timezone
+	maxDepth   int            // deepest reply level kept, 0 = unlimited
+
+	// userAgents, when set, replaces userAgent with a round-robin rotation.
+	userAgents []string
+	uaNext     atomic.Uint64
+
+	auth   atomic.Int32 // AuthState
+	onAuth atomic.Pointer[func(AuthState)]
+
+	cacheDir string     // offline comment cache, "" = disabled
+	cache    cacheState // what this session saved there
+
+	oauth oauthState // app-only OAuth, see SetCredentials
+
+	throttle  throttleState // shared 429/503 backoff
+	retryBase time.Duration // first backoff without Retry-After
+
+	allowedSubs map[string]bool // nil allows every subreddit
+
+	searchTimeout time.Duration // thread listings, 0 = the client timeout
+}
+
+// Request timeouts. SetTimeout and SetSearchTimeout raise values below
+// MinTimeout to it.
+const (
+	DefaultTimeout = 15 * time.Second
+	MinTimeout     = 3 * time.Second
+)
+
+// requestKind tells get what a request fetches, which decides its caching
+// headers and timeout.
+type requestKind int
+
+const (
+	commentsRequest requestKind = iota // comments, never served from caches
+	listingRequest                     // thread searches and listings
+)
+
+// NewClient returns a Client that identifies itself with userAgent.
+func NewClient(userAgent string) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		userAgent:  userAgent,
+		retryBase:  time.Second,
+	}
+}
+
+// SetTimeout sets how long a request may take, including reading the
+// response. 0 restores DefaultTimeout.
+func (c *Client) SetTimeout(d time.Duration) {
+	if d == 0 {
+		d = DefaultTimeout
+	}
+	c.httpClient.Timeout = max(d, MinTimeout)
+}
+
+// SetSearchTimeout sets a separate timeout for thread searches and
+// subreddit listings, which can be slower than comment fetches. 0 uses
+// the SetTimeout value.
+func (c *Client) SetSearchTimeout(d time.Duration) {
+	if d > 0 {
+		d = max(d, MinTimeout)
+	}
+	c.searchTimeout = max(d, 0)
+}
+
+// httpFor returns the HTTP client for a request of kind, a copy with the
+// search timeout for listings when one is set.
+func (c *Client) httpFor(kind requestKind) *http.Client {
+	if kind != listingRequest || c.searchTimeout == 0 {
+		return c.httpClient
+	}
+	hc := *c.httpClient
+	hc.Timeout = c.searchTimeout
+	return &hc
+}
+
+// SetUserAgents configures a list of user agents rotated round-robin per
+// request. An empty list restores the single user agent from NewClient.
+func (c *Client) SetUserAgents(agents []string) {
+	c.userAgents = nil
+	for _, agent := range agents {
+		if agent = strings.TrimSpace(agent); agent != "" {
+			c.userAgents = append(c.userAgents, agent)
+		}
+	}
+}
+
+func (c *Client) nextUserAgent() string {
+	if len(c.userAgents) == 0 {
+		return c.userAgent
+	}
+	n := c.uaNext.Add(1) - 1
+	return c.userAgents[n%uint64(len(c.userAgents))]
+}
+
+// SetMaxDepth limits how deep reply chains are loaded. Replies below
+// depth (0 = top-level) are not returned; their IDs are recorded on the
+// cut-off comment's MoreChildren instead. 0 means unlimited.
+func (c *Client) SetMaxDepth(depth int) {
+	if depth < 0 {
+		depth = 0
+	}
+	c.maxDepth = depth
+}
+
+// Location returns the timezone used for comment timestamps.
+func (c *Client) Location() *time.Location {
+	if c.location == nil {
+		return time.Local
+	}
+	return c.location
+}
+
+// SetTimezone sets the timezone used for comment timestamps. Accepts
+// "local" (or empty), "UTC", or an IANA name such as "Europe/London".
+func (c *Client) SetTimezone(name string) error {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "local":
+		c.location = nil
+		return nil
+	case "utc":
+		c.location = time.UTC
+		return nil
+	}
+	loc, err := time.LoadLocation(strings.TrimSpace(name))
+	if err != nil {
+		return fmt.Errorf("load timezone %q: %w", name, err)
+	}
+	c.location = loc
+	return nil
+}
+
+// get issues a GET request for urlStr and returns the response when it
+// succeeds with 200 OK. Other statuses are returned as a *StatusError.
+// op names the operation in error messages. With OAuth credentials the
+// request goes to oauth.reddit.com with a bearer token; a rejected token is
+// renewed once, and when no token can be had the request is sent
+// anonymously. 429 and 503 answers are retried up to maxRetries times
+// after a backoff that every request of the client honours.
+func (c *Client) get(ctx context.Context, op, urlStr string, kind requestKind) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.waitBackoff(ctx); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		resp, err := c.getOnce(ctx, op, urlStr, kind)
+		if err != nil {
+			return nil, err
+		}
+		if throttled(resp.StatusCode) && attempt < maxRetries {
+			wait := retryDelay(resp.Header.Get("Retry-After"), attempt, c.retryBase, time.Now())
+			resp.Body.Close()
+			c.backOff(wait)
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, &StatusError{Op: op, StatusCode: resp.StatusCode}
+		}
+		return resp, nil
+	}
+}
+
+// getOnce sends the request, renewing a rejected OAuth token once.
+func (c *Client) getOnce(ctx context.Context, op, urlStr string, kind requestKind) (*http.Response, error) {
+	var token string
+	if c.HasCredentials() {
+		token, _ = c.bearer(ctx)
+	}
+	resp, err := c.send(ctx, op, urlStr, kind, token)
+	if err == nil && token != "" && resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		c.dropToken(token)
+		token, _ = c.bearer(ctx)
+		resp, err = c.send(ctx, op, urlStr, kind, token)
+	}
+	return resp, err
+}
+
+// send issues one GET request, authenticated with token unless it is "".
+func (c *Client) send(ctx context.Context, op, urlStr string, kind requestKind, token string) (*http.Response, error) {
+	if token != "" {
+		urlStr = oauthURL(urlStr)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build %s request: %w", op, err)
+	}
+	req.Header.Set("User-Agent", c.nextUserAgent())
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if kind == commentsRequest {
+		req.Header.Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		req.Header.Set("Pragma", "no-cache")
+	}
+
+	resp, err := c.httpFor(kind).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return resp, nil
+}
+
+// FetchComments is FetchCommentsContext with a background context.
+func (c *Client) FetchComments(permalink string) ([]Comment, Post, error) {
+	return c.FetchCommentsContext(context.Background(), permalink)
+}
+
+// FetchCommentsContext loads the post and comments at permalink (for
+// example "/r/soccer/comments/abc123/match_thread") sorted newest first.
+// Comments are returned flattened in thread order with ParentID and Depth
+// describing the tree. Deleted and removed comments are skipped.
+func (c *Client) FetchCommentsContext(ctx context.Context, permalink string) ([]Comment, Post, error) {
+	return c.FetchCommentsSortedContext(ctx, permalink, SortNew)
+}
+
+// FetchCommentsSorted is FetchCommentsSortedContext with a background
+// context.
+func (c *Client) FetchCommentsSorted(permalink string, sort CommentSort) ([]Comment, Post, error) {
+	return c.FetchCommentsSortedContext(context.Background(), permalink, sort)
+}
+
+// commentsURL builds the JSON URL for a thread's comments, with a cache
+// buster so refreshes are never served stale.
+func commentsURL(permalink string, sort CommentSort) string {
+	if sort == "" {
+		sort = SortNew
+	}
+	clean := strings.Trim(permalink, "/")
+	return fmt.Sprintf("https://www.reddit.com/%s.json?sort=%s&limit=200&raw_json=1&_=%d", clean, url.QueryEscape(string(sort)), time.Now().UnixNano())
+}
+
+// FetchRaw is FetchRawContext with a background context.
+func (c *Client) FetchRaw(permalink string) ([]byte, error) {
+	return c.FetchRawContext(context.Background(), permalink)
+}
+
+// FetchRawContext returns the unparsed JSON body FetchComments would
+// decode for permalink, for debugging threads that parse oddly.
+func (c *Client) FetchRawContext(ctx context.Context, permalink string) ([]byte, error) {
+	return c.FetchRawSortedContext(ctx, permalink, SortNew)
+}
+
+// FetchRawSorted is FetchRawSortedContext with a background context.
+func (c *Client) FetchRawSorted(permalink string, sort CommentSort) ([]byte, error) {
+	return c.FetchRawSortedContext(context.Background(), permalink, sort)
+}
+
+// FetchRawSortedContext is FetchRawContext with a server-side sort, the
+// body FetchCommentsSortedContext would decode.
+func (c *Client) FetchRawSortedContext(ctx context.Context, permalink string, sort CommentSort) ([]byte, error) {
+	resp, err := c.get(ctx, "fetch comments", commentsURL(permalink, sort), commentsRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read comments: %w", err)
+	}
+	return body, nil
+}
+
+// FetchCommentsSortedContext is FetchCommentsContext with a server-side
+// sort. An empty sort means SortNew.
+//
+// With an offline cache set, a failed fetch returns the last saved copy
+// with Post.Offline set and a nil error instead of no comments.
+func (c *Client) FetchCommentsSortedContext(ctx context.Context, permalink string, sort CommentSort) ([]Comment, Post, error) {
+	comments, post, err := c.fetchComments(ctx, permalink, sort)
+	if err == nil {
+		c.saveCache(permalink, sort, comments, post, time.Now())
+		return comments, post, nil
+	}
+	if offlineFallback(err) {
+		if entry, ok := c.loadCache(permalink, sort); ok {
+			entry.Post.Offline = &OfflineCopy{FetchedAt: entry.FetchedAt, Err: err}
+			return entry.Comments, entry.Post, nil
+		}
+	}
+	return nil, Post{}, err
+}
+
+func (c *Client) fetchComments(ctx context.Context, permalink string, sort CommentSort) ([]Comment, Post, error) {
+	urlStr := commentsURL(permalink, sort)
+
+	resp, err := c.get(ctx, "fetch comments", urlStr, commentsRequest)
+	if err != nil {
+		return nil, Post{}, err
+	}
+	defer resp.Body.Close()
+
+	var payload []listing
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, Post{}, fmt.Errorf("decode comments: %w", err)
+	}
+	if len(payload) < 2 {
+		return nil, Post{}, fmt.Errorf("comments payload missing")
+	}
+
+	post := extractPost(payload[0])
+	if post.ID == "" {
+		return nil, Post{}, fmt.Errorf("missing post id")
+	}
+
+	comments := make([]Comment, 0, 256)
+	for _, thing := range payload[1].Data.Children {
+		switch thing.Kind {
+		case "t1":
+			c.processComment(thing.Data, post.ID, 0, &comments)
+		case "more":
+			post.MoreChildren = append(post.MoreChildren, moreChildren(thing.Data)...)
+		}
+	}
+
+	return comments, post, nil
+}
+
+// FindThreads is FindThreadsContext with a background context.
+func (c *Client) FindThreads(cfg ThreadQuery) ([]Thread, error) {
+	return c.FindThreadsContext(context.Background(), cfg)
+}
+
+// maxSearchPages caps how many result pages FindThreadsContext reads per
+// subreddit while looking for cfg.Limit matching threads.
+const maxSearchPages = 5
+
+// FindThreadsContext searches cfg.Subreddit (or each of cfg.Subreddits)
+// for threads with one of cfg.Flairs, using a single query per subreddit.
+// Results for every subreddit are merged, deduplicated, sorted newest first
+// and capped at cfg.Limit.
+// When title or age filters reject results, further pages are read, up to
+// maxSearchPages per search.
+func (c *Client) FindThreadsContext(ctx context.Context, cfg ThreadQuery) ([]Thread, error) {
+	threads := make([]Thread, 0, 64)
+	seen := make(map[string]bool)
+
+	if len(cfg.Flairs) == 0 {
+		return threads, nil
+	}
+	q := flairQuery(cfg.Flairs)
+	subreddits := cfg.Subreddits
+	if len(subreddits) == 0 {
+		subreddits = []string{cfg.Subreddit}
+	}
+	for _, subreddit := range subreddits {
+		if err := c.searchThreads(ctx, cfg, subreddit, q, seen, &threads); err != nil {
+			return nil, err
+		}
+	}
+
+	sortThreads(threads)
+	if cfg.Limit > 0 && len(threads) > cfg.Limit {
+		threads = threads[:cfg.Limit]
+	}
+	return threads, nil
+}
+
+// flairQuery is the search expression matching any of flairs:
+// flair:"A" OR flair:"B".
+func flairQuery(flairs []string) string {
+	terms := make([]string, len(flairs))
+	for i, flair := range flairs {
+		terms[i] = fmt.Sprintf("flair:\"%s\"", flair)
+	}
+	return strings.Join(terms, " OR ")
+}
+
+// searchThreads runs the search q in subreddit, appending matches not
+// already in seen to threads.
+func (c *Client) searchThreads(ctx context.Context, cfg ThreadQuery, subreddit, q string, seen map[string]bool, threads *[]Thread) error {
+	found, after := 0, ""
+	for page := 0; page < maxSearchPages; page++ {
+		query := url.Values{}
+		query.Set("q", q)
+		query.Set("sort", "new")
+		query.Set("t", "week")
+		query.Set("limit", fmt.Sprintf("%d", cfg.Limit))
+		query.Set("restrict_sr", "1")
+		query.Set("raw_json", "1")
+		if after != "" {
+			query.Set("after", after)
+		}
+		urlStr := fmt.Sprintf("https://www.reddit.com/r/%s/search.json?%s", subreddit, query.Encode())
+
+		listing, err := c.fetchListing(ctx, urlStr)
+		if err != nil {
+			return err
+		}
+
+		tooOld := false
+		for _, thing := range listing.Data.Children {
+			if thing.Kind != "t3" {
+				continue
+			}
+			var post postData
+			if err := json.Unmarshal(thing.Data, &post); err != nil {
+				continue
+			}
+			post.unescape()
+			if !cfg.WithinAge(post.CreatedUTC) {
+				tooOld = true
+				continue
+			}
+			if !cfg.TitleMatches(post.Title) || seen[post.ID] {
+				continue
+			}
+			seen[post.ID] = true
+			found++
+			*threads = append(*threads, post.thread(cfg.Type))
+		}
+
+		// Results are newest first, so once one is too old the following
+		// pages are as well
+		after = listing.Data.After
+		if after == "" || tooOld || (cfg.Limit > 0 && found >= cfg.Limit) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// fetchListing loads and decodes one page of a thread listing.
+func (c *Client) fetchListing(ctx context.Context, urlStr string) (listing, error) {
+	resp, err := c.get(ctx, "fetch threads", urlStr, listingRequest)
+	if err != nil {
+		return listing{}, err
+	}
+	defer resp.Body.Close()
+
+	var l listing
+	if err := json.NewDecoder(resp.Body).Decode(&l); err != nil {
+		return listing{}, fmt.Errorf("decode threads: %w", err)
+	}
+	return l, nil
+}
+
+// sortThreads orders threads merged from several searches newest first,
+// then by score, then by ID, so the list is identical across refreshes
+// whatever order the searches answered in.
+func sortThreads(threads []Thread) {
+	sort.SliceStable(threads, func(i, j int) bool {
+		a, b := threads[i], threads[j]
+		if a.CreatedUTC != b.CreatedUTC {
+			return a.CreatedUTC > b.CreatedUTC
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.ID < b.ID
+	})
+}
+
+// ListSubreddit is ListSubredditContext with a background context.
+func (c *Client) ListSubreddit(subreddit string, limit int) ([]Thread, error) {
+	return c.ListSubredditContext(context.Background(), subreddit, limit)
+}
+
+// ListSubredditContext returns the newest threads in subreddit, at most
+// limit of them. Threads get the type "subreddit".
+func (c *Client) ListSubredditContext(ctx context.Context, subreddit string, limit int) ([]Thread, error) {
+	name := strings.TrimPrefix(strings.Trim(strings.TrimSpace(subreddit), "/"), "r/")
+	if name == "" {
+		return nil, fmt.Errorf("empty subreddit")
+	}
+	query := url.Values{}
+	query.Set("limit", fmt.Sprintf("%d", limit))
+	query.Set("raw_json", "1")
+	urlStr := fmt.Sprintf("https://www.reddit.com/r/%s/new.json?%s", url.PathEscape(name), query.Encode())
+
+	listing, err := c.fetchListing(ctx, urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make([]Thread, 0, len(listing.Data.Children))
+	for _, thing := range listing.Data.Children {
+		if thing.Kind != "t3" {
+			continue
+		}
+		var post postData
+		if err := json.Unmarshal(thing.Data, &post); err != nil {
+			continue
+		}
+		post.unescape()
+		threads = append(threads, post.thread("subreddit"))
+	}
+	return threads, nil
+}
+
+// ListSubreddits is ListSubredditsContext with a background context.
+func (c *Client) ListSubreddits(subreddits []string, limit int) ([]Thread, error) {
+	return c.ListSubredditsContext(context.Background(), subreddits, limit)
+}
+
+// ListSubredditsContext lists the newest threads of every subreddit,
+// merged newest first, deduplicated and capped at limit.
+func (c *Client) ListSubredditsContext(ctx context.Context, subreddits []string, limit int) ([]Thread, error) {
+	var threads []Thread
+	seen := make(map[string]bool)
+	for _, subreddit := range subreddits {
+		listed, err := c.ListSubredditContext(ctx, subreddit, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, thread := range listed {
+			if !seen[thread.ID] {
+				seen[thread.ID] = true
+				threads = append(threads, thread)
+			}
+		}
+	}
+	sortThreads(threads)
+	if limit > 0 && len(threads) > limit {
+		threads = threads[:limit]
+	}
+	return threads, nil
+}
+
+// ThreadFromURL is ThreadFromURLContext with a background context.
+func (c *Client) ThreadFromURL(input string) (Thread, error) {
+	return c.ThreadFromURLContext(context.Background(), input)
+}
+
+// ThreadFromURLContext resolves a thread URL or permalink to a Thread,
+// fetching it once to read the title. Malformed input returns an error
+// wrapping ErrInvalidURL; a subreddit outside SetAllowedSubreddits returns
+// one wrapping ErrSubredditNotAllowed.
+func (c *Client) ThreadFromURLContext(ctx context.Context, input string) (Thread, error) {
+	permalink, err := normalizePermalink(input)
+	if err != nil {
+		return Thread{}, err
+	}
+
+	threadID := extractThreadID(permalink)
+	if threadID == "" {
+		return Thread{}, fmt.Errorf("%w: invalid thread id", ErrInvalidURL)
+	}
+	if err := c.CheckSubreddit(PermalinkSubreddit(permalink)); err != nil {
+		return Thread{}, err
+	}
+
+	_, post, err := c.FetchCommentsContext(ctx, permalink)
+	if err != nil {
+		return Thread{}, err
+	}
+
+	return Thread{
+		ID:        threadID,
+		Title:     post.Title,
+		Permalink: permalink,
+		Type:      "url_input",
+		Subreddit: PermalinkSubreddit(permalink),
+		MediaURL:  post.MediaURL,
+	}, nil
+}
+
+func normalizePermalink(input string) (string, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return "", fmt.Errorf("%w: empty url", ErrInvalidURL)
+	}
+
+	if strings.HasPrefix(trimmed, "http") {
+		parsed, err := url.Parse(trimmed)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidURL, err)
+		}
+		trimmed = parsed.Path
+	}
+
+	trimmed = strings.TrimSuffix(trimmed, ".json")
+	trimmed = strings.TrimSuffix(trimmed, "/")
+	if !strings.HasPrefix(trimmed, "/") {
+		trimmed = "/" + trimmed
+	}
+	return trimmed, nil
+}
+
+func extractThreadID(permalink string) string {
+	parts := strings.Split(strings.Trim(permalink, "/"), "/")
+	if len(parts) >= 4 && parts[0] == "r" && parts[2] == "comments" {
+		return parts[3]
+	}
+	return ""
+}
+
+func extractPost(listing listing) Post {
+	if len(listing.Data.Children) == 0 {
+		return Post{}
+	}
+	thing := listing.Data.Children[0]
+	if thing.Kind != "t3" {
+		return Post{}
+	}
+	var post postData
+	if err := json.Unmarshal(thing.Data, &post); err != nil {
+		return Post{}
+	}
+	post.unescape()
+	return Post{
+		ID:       post.ID,
+		Title:    post.Title,
+		Author:   post.Author,
+		SelfText: post.SelfText,
+		URL:      post.linkURL(),
+		MediaURL: post.mediaURL(),
+	}
+}
+
+func (c *Client) processComment(raw json.RawMessage, postID string, depth int, out *[]Comment) {
+	var comment redditComment
+	if err := json.Unmarshal(raw, &comment); err != nil {
+		return
+	}
+	if comment.Body == "[deleted]" || comment.Body == "[removed]" {
+		return
+	}
+
+	// Every root of a comments listing replies to the post, so any t3_
+	// parent is accepted rather than compared with postID, which can differ
+	// in form from the comment's link (or be empty when the post is
+	// missing). Only a reply (t1_) showing up at the root is stray.
+	if depth == 0 && !strings.HasPrefix(comment.ParentID, "t3_") {
+		return
+	}
+
+	*out = append(*out, c.newComment(comment, depth))
+	idx := len(*out) - 1
+
+	if len(comment.Replies) == 0 || string(comment.Replies) == "\"\"" {
+		return
+	}
+
+	var replyListing listing
+	if err := json.Unmarshal(comment.Replies, &replyListing); err != nil {
+		return
+	}
+
+	cutOff := c.maxDepth > 0 && depth >= c.maxDepth
+	for _, child := range replyListing.Data.Children {
+		switch {
+		case child.Kind == "more":
+			(*out)[idx].MoreChildren = append((*out)[idx].MoreChildren, moreChildren(child.Data)...)
+		case child.Kind != "t1":
+		case cutOff:
+			var ref struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(child.Data, &ref); err == nil && ref.ID != "" {
+				(*out)[idx].MoreChildren = append((*out)[idx].MoreChildren, ref.ID)
+			}
+		default:
+			c.processComment(child.Data, postID, depth+1, out)
+		}
+	}
+}
+
+// newComment converts a decoded comment at depth into a Comment. Parents
+// are stored without their t1_ prefix; replies to the post get none.
+func (c *Client) newComment(comment redditComment, depth int) Comment {
+	parentID := strings.TrimPrefix(comment.ParentID, "t1_")
+	if strings.HasPrefix(comment.ParentID, "t3_") {
+		parentID = ""
+	}
+	return Comment{
+		ID:            comment.ID,
+		Author:        fallback(comment.Author, "[deleted]"),
+		Body:          html.UnescapeString(comment.Body),
+		CreatedUTC:    comment.CreatedUTC,
+		FormattedTime: formatTimestamp(comment.CreatedUTC, c.location),
+		Score:         comment.Score,
+		Depth:         depth,
+		ParentID:      parentID,
+		Edited:        comment.Edited.Edited,
+		EditedUTC:     comment.Edited.At,
+		Stickied:      comment.Stickied,
+		ScoreHidden:   comment.ScoreHidden,
+	}
+}
+
+// formatTimestamp renders ts in loc, or in local time when loc is nil.
+// An explicit timezone gets a zone label so times are unambiguous.
+func formatTimestamp(ts float64, loc *time.Location) string {
+	if ts == 0 {
+		return ""
+	}
+	if loc == nil {
+		return time.Unix(int64(ts), 0).Local().Format("2006-01-02 15:04:05")
+	}
+	return time.Unix(int64(ts), 0).In(loc).Format("2006-01-02 15:04:05 MST")
+}
+
+func fallback(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,1360 @@
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// redirectTransport rewrites all outbound requests to the given test server,
+// so we can exercise HTTP logic without hitting reddit.com.
+type redirectTransport struct {
+	srv *httptest.Server
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cloned := *req
+	clonedURL := *req.URL
+	clonedURL.Scheme = "http"
+	clonedURL.Host = t.srv.Listener.Addr().String()
+	cloned.URL = &clonedURL
+	return http.DefaultTransport.RoundTrip(&cloned)
+}
+
+func newTestClient(srv *httptest.Server) *Client {
+	return &Client{
+		httpClient: &http.Client{Transport: &redirectTransport{srv: srv}},
+		userAgent:  "test",
+	}
+}
+
+// — normalizePermalink —
+
+func TestNormalizePermalink(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"/r/soccer/comments/abc123/match_thread/", "/r/soccer/comments/abc123/match_thread"},
+		{"r/soccer/comments/abc123/match_thread", "/r/soccer/comments/abc123/match_thread"},
+		{"https://www.reddit.com/r/soccer/comments/abc123/match_thread/", "/r/soccer/comments/abc123/match_thread"},
+		{"https://www.reddit.com/r/soccer/comments/abc123/match_thread.json", "/r/soccer/comments/abc123/match_thread"},
+		{"/r/FantasyPL/comments/xyz789/", "/r/FantasyPL/comments/xyz789"},
+	}
+	for _, tc := range cases {
+		got, err := normalizePermalink(tc.input)
+		if err != nil {
+			t.Errorf("normalizePermalink(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("normalizePermalink(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizePermalinkEmpty(t *testing.T) {
+	for _, input := range []string{"", "   "} {
+		if _, err := normalizePermalink(input); err == nil {
+			t.Errorf("expected error for input %q", input)
+		}
+	}
+}
+
+// — extractThreadID —
+
+func TestExtractThreadID(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"/r/soccer/comments/abc123/match_thread", "abc123"},
+		{"/r/FantasyPL/comments/xyz789/", "xyz789"},
+		{"/r/soccer/comments/abc123", "abc123"},
+		{"not/a/valid/path", ""},
+		{"", ""},
+	}
+	for _, tc := range cases {
+		got := extractThreadID(tc.input)
+		if got != tc.want {
+			t.Errorf("extractThreadID(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+// — ThreadQuery methods —
+
+func TestWithinAge(t *testing.T) {
+	now := float64(time.Now().Unix())
+	q := ThreadQuery{MaxAgeHours: 2}
+
+	if !q.WithinAge(now - 3600) {
+		t.Error("post from 1h ago should be within 2h limit")
+	}
+	if q.WithinAge(now - 10800) {
+		t.Error("post from 3h ago should be outside 2h limit")
+	}
+}
+
+func TestWithinAgeZeroMeansUnlimited(t *testing.T) {
+	q := ThreadQuery{MaxAgeHours: 0}
+	if !q.WithinAge(0) {
+		t.Error("MaxAgeHours=0 should always return true")
+	}
+}
+
+func TestTitleMatches(t *testing.T) {
+	q := ThreadQuery{
+		TitleMustContain:    []string{"match thread"},
+		TitleMustNotContain: []string{"post match"},
+	}
+	cases := []struct {
+		title string
+		want  bool
+	}{
+		{"Match Thread: Arsenal vs Chelsea", true},
+		{"Post Match Thread: Arsenal vs Chelsea", false},
+		{"Arsenal vs Chelsea", false},
+		{"MATCH THREAD: Liverpool vs City", true},
+	}
+	for _, tc := range cases {
+		got := q.TitleMatches(tc.title)
+		if got != tc.want {
+			t.Errorf("TitleMatches(%q) = %v, want %v", tc.title, got, tc.want)
+		}
+	}
+}
+
+// — small helpers —
+
+func TestFallback(t *testing.T) {
+	if fallback("", "default") != "default" {
+		t.Error("expected fallback for empty string")
+	}
+	if fallback("value", "default") != "value" {
+		t.Error("expected original value when non-empty")
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	if formatTimestamp(0, nil) != "" {
+		t.Error("expected empty string for zero timestamp")
+	}
+	if got := formatTimestamp(1700000000, nil); len(got) == 0 {
+		t.Error("expected non-empty formatted timestamp")
+	}
+}
+
+func TestFormatTimestampFixedZone(t *testing.T) {
+	if got, want := formatTimestamp(1700000000, time.UTC), "2023-11-14 22:13:20 UTC"; got != want {
+		t.Errorf("formatTimestamp UTC = %q, want %q", got, want)
+	}
+	cet := time.FixedZone("CET", 3600)
+	if got, want := formatTimestamp(1700000000, cet), "2023-11-14 23:13:20 CET"; got != want {
+		t.Errorf("formatTimestamp CET = %q, want %q", got, want)
+	}
+}
+
+func TestSetTimezone(t *testing.T) {
+	c := NewClient("test")
+	if err := c.SetTimezone("UTC"); err != nil || c.location != time.UTC {
+		t.Errorf("SetTimezone(UTC) = %v, location %v", err, c.location)
+	}
+	if err := c.SetTimezone("local"); err != nil || c.location != nil {
+		t.Errorf("SetTimezone(local) = %v, location %v", err, c.location)
+	}
+	if err := c.SetTimezone("Not/AZone"); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+}
+
+// — extractPost —
+
+func TestExtractPost(t *testing.T) {
+	postJSON, _ := json.Marshal(postData{ID: "abc123", Title: "Match Thread", Author: "mod", SelfText: "Lineups below"})
+	l := listing{Data: listingData{Children: []thing{{Kind: "t3", Data: postJSON}}}}
+
+	post := extractPost(l)
+	if post.ID != "abc123" {
+		t.Errorf("extractPost id = %q, want %q", post.ID, "abc123")
+	}
+	if post.Title != "Match Thread" {
+		t.Errorf("extractPost title = %q, want %q", post.Title, "Match Thread")
+	}
+	if post.SelfText != "Lineups below" {
+		t.Errorf("extractPost selftext = %q, want %q", post.SelfText, "Lineups below")
+	}
+	if post.Author != "mod" {
+		t.Errorf("extractPost author = %q, want %q", post.Author, "mod")
+	}
+}
+
+func TestPostDataMediaURL(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"self post", `{"url":"https://www.reddit.com/r/soccer/comments/abc123/"}`, ""},
+		{"plain link", `{"url":"https://example.com/story","post_hint":"link"}`, ""},
+		{"image", `{"url":"https://i.redd.it/x.jpg","post_hint":"image"}`, "https://i.redd.it/x.jpg"},
+		{"gallery", `{"url":"https://www.reddit.com/gallery/abc123","is_gallery":true}`, "https://www.reddit.com/gallery/abc123"},
+		{"video", `{"url":"https://v.redd.it/abc","is_video":true,"media":{"reddit_video":{"fallback_url":"https://v.redd.it/abc/DASH_720.mp4"}}}`, "https://v.redd.it/abc/DASH_720.mp4"},
+		{"preview only", `{"post_hint":"image","preview":{"images":[{"source":{"url":"https://preview.redd.it/x.jpg?a=1&amp;b=2"}}]}}`, "https://preview.redd.it/x.jpg?a=1&b=2"},
+	}
+	for _, tc := range cases {
+		var p postData
+		if err := json.Unmarshal([]byte(tc.raw), &p); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got := p.mediaURL(); got != tc.want {
+			t.Errorf("%s: mediaURL() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestExtractPostLinkURL(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"self post", `{"is_self":true,"url":"https://www.reddit.com/r/soccer/comments/abc123/"}`, ""},
+		{"link post", `{"url":"https://example.com/story?a=1&amp;b=2"}`, "https://example.com/story?a=1&b=2"},
+	}
+	for _, tc := range cases {
+		l := listing{Data: listingData{Children: []thing{{Kind: "t3", Data: json.RawMessage(tc.raw)}}}}
+		if got := extractPost(l).URL; got != tc.want {
+			t.Errorf("%s: URL = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestExtractPostEmptyListing(t *testing.T) {
+	post := extractPost(listing{})
+	if post.ID != "" || post.Title != "" {
+		t.Error("expected empty id and title for empty listing")
+	}
+}
+
+func TestExtractPostWrongKind(t *testing.T) {
+	l := listing{Data: listingData{Children: []thing{{Kind: "t1", Data: json.RawMessage(`{}`)}}}}
+	if post := extractPost(l); post.ID != "" {
+		t.Error("expected empty id for non-t3 kind")
+	}
+}
+
+// — processComment —
+
+func TestProcessComment(t *testing.T) {
+	c := NewClient("test")
+	raw, _ := json.Marshal(redditComment{
+		ID:       "c1",
+		Author:   "alice",
+		Body:     "hello",
+		Score:    3,
+		ParentID: "t3_post1",
+		Replies:  json.RawMessage(`""`),
+	})
+
+	var out []Comment
+	c.processComment(raw, "post1", 0, &out)
+
+	if len(out) != 1 {
+		t.Fatalf("expected 1 comment, got %d", len(out))
+	}
+	got := out[0]
+	if got.Author != "alice" || got.Body != "hello" || got.Score != 3 {
+		t.Errorf("unexpected comment fields: %+v", got)
+	}
+	if got.ParentID != "" {
+		t.Errorf("top-level comment ParentID should be empty, got %q", got.ParentID)
+	}
+}
+
+func TestProcessCommentDeletedSkipped(t *testing.T) {
+	c := NewClient("test")
+	for _, body := range []string{"[deleted]", "[removed]"} {
+		raw, _ := json.Marshal(redditComment{ID: "c1", Author: "x", Body: body, ParentID: "t3_post1"})
+		var out []Comment
+		c.processComment(raw, "post1", 0, &out)
+		if len(out) != 0 {
+			t.Errorf("expected %q comment to be skipped", body)
+		}
+	}
+}
+
+func TestProcessCommentStrayReplySkipped(t *testing.T) {
+	c := NewClient("test")
+	raw, _ := json.Marshal(redditComment{ID: "c1", Author: "x", Body: "hi", ParentID: "t1_other"})
+	var out []Comment
+	c.processComment(raw, "post1", 0, &out)
+	if len(out) != 0 {
+		t.Error("expected a reply at depth 0 to be skipped")
+	}
+}
+
+func TestProcessCommentTopLevelParentTolerant(t *testing.T) {
+	c := NewClient("test")
+	for _, postID := range []string{"POST1", ""} {
+		raw, _ := json.Marshal(redditComment{ID: "c1", Author: "x", Body: "hi", ParentID: "t3_post1"})
+		var out []Comment
+		c.processComment(raw, postID, 0, &out)
+		if len(out) != 1 || out[0].ParentID != "" {
+			t.Errorf("postID %q: expected top-level comment to be kept, got %+v", postID, out)
+		}
+	}
+}
+
+func TestProcessCommentWithReplies(t *testing.T) {
+	c := NewClient("test")
+
+	replyJSON, _ := json.Marshal(redditComment{
+		ID:       "c2",
+		Author:   "bob",
+		Body:     "reply",
+		ParentID: "t1_c1",
+		Replies:  json.RawMessage(`""`),
+	})
+	replyListing, _ := json.Marshal(listing{
+		Data: listingData{Children: []thing{{Kind: "t1", Data: replyJSON}}},
+	})
+
+	raw, _ := json.Marshal(redditComment{
+		ID:       "c1",
+		Author:   "alice",
+		Body:     "hello",
+		ParentID: "t3_post1",
+		Replies:  replyListing,
+	})
+
+	var out []Comment
+	c.processComment(raw, "post1", 0, &out)
+
+	if len(out) != 2 {
+		t.Fatalf("expected 2 comments (parent + reply), got %d", len(out))
+	}
+	if out[0].Depth != 0 || out[1].Depth != 1 {
+		t.Errorf("unexpected depths: %d, %d", out[0].Depth, out[1].Depth)
+	}
+	if out[1].ParentID != "c1" {
+		t.Errorf("reply ParentID = %q, want %q", out[1].ParentID, "c1")
+	}
+}
+
+// — FetchComments (HTTP) —
+
+func buildCommentsPayload(postID, title, commentBody string) []byte {
+	postJSON, _ := json.Marshal(postData{
+		ID:        postID,
+		Title:     title,
+		Permalink: "/r/test/comments/" + postID + "/thread/",
+	})
+	commentJSON, _ := json.Marshal(redditComment{
+		ID:       "c1",
+		Author:   "user1",
+		Body:     commentBody,
+		Score:    1,
+		ParentID: "t3_" + postID,
+		Replies:  json.RawMessage(`""`),
+	})
+	payload := []listing{
+		{Data: listingData{Children: []thing{{Kind: "t3", Data: postJSON}}}},
+		{Data: listingData{Children: []thing{{Kind: "t1", Data: commentJSON}}}},
+	}
+	b, _ := json.Marshal(payload)
+	return b
+}
+
+func TestFetchComments(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(buildCommentsPayload("abc123", "Match Thread", "Great goal!"))
+	}))
+	defer srv.Close()
+
+	comments, post, err := newTestClient(srv).FetchComments("/r/test/comments/abc123/thread/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if post.Title != "Match Thread" {
+		t.Errorf("title = %q, want %q", post.Title, "Match Thread")
+	}
+	if len(comments) != 1 || comments[0].Body != "Great goal!" {
+		t.Errorf("unexpected comments: %+v", comments)
+	}
+}
+
+func TestFetchCommentsHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	_, _, err := newTestClient(srv).FetchComments("/r/test/comments/abc123/thread/")
+	if err == nil {
+		t.Fatal("expected error for non-200 response")
+	}
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected *StatusError with 429, got %v", err)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected errors.Is(err, ErrRateLimited), got %v", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("429 should not match ErrNotFound")
+	}
+}
+
+func TestFetchRaw(t *testing.T) {
+	payload := buildCommentsPayload("abc123", "Match Thread", "Great goal!")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer srv.Close()
+
+	raw, err := newTestClient(srv).FetchRaw("/r/test/comments/abc123/thread/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != string(payload) {
+		t.Errorf("FetchRaw returned %d bytes, want the %d-byte payload unchanged", len(raw), len(payload))
+	}
+}
+
+func TestFetchCommentsSortedQuery(t *testing.T) {
+	var gotSort string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSort = r.URL.Query().Get("sort")
+		w.Write(buildCommentsPayload("abc123", "Match Thread", "Great goal!"))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv)
+	if _, _, err := client.FetchCommentsSorted("/r/test/comments/abc123/thread/", SortTop); err != nil {
+		t.Fatal(err)
+	}
+	if gotSort != "top" {
+		t.Errorf("sort = %q, want top", gotSort)
+	}
+	if _, _, err := client.FetchComments("/r/test/comments/abc123/thread/"); err != nil {
+		t.Fatal(err)
+	}
+	if gotSort != "new" {
+		t.Errorf("default sort = %q, want new", gotSort)
+	}
+}
+
+func TestFetchCommentsContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(buildCommentsPayload("abc123", "Match Thread", "Great goal!"))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := newTestClient(srv).FetchCommentsContext(ctx, "/r/test/comments/abc123/thread/")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestThreadFromURLInvalid(t *testing.T) {
+	_, err := NewClient("test").ThreadFromURL("https://www.reddit.com/r/soccer/")
+	if !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("expected ErrInvalidURL, got %v", err)
+	}
+}
+
+// — FindThreads (HTTP) —
+
+func buildSearchPayload(postID, title string) []byte {
+	postJSON, _ := json.Marshal(postData{
+		ID:          postID,
+		Title:       title,
+		Permalink:   "/r/soccer/comments/" + postID + "/",
+		CreatedUTC:  float64(time.Now().Unix()),
+		NumComments: 42,
+	})
+	l := listing{Data: listingData{Children: []thing{{Kind: "t3", Data: postJSON}}}}
+	b, _ := json.Marshal(l)
+	return b
+}
+
+func TestFindThreads(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(buildSearchPayload("abc123", "Match Thread: Test vs Test"))
+	}))
+	defer srv.Close()
+
+	threads, err := newTestClient(srv).FindThreads(ThreadQuery{
+		Type:      "match",
+		Subreddit: "soccer",
+		Flairs:    []string{"match thread"},
+		Limit:     10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(threads) != 1 || threads[0].ID != "abc123" || threads[0].NumComments != 42 {
+		t.Errorf("unexpected threads: %+v", threads)
+	}
+}
+
+func TestFindThreadsCombinesFlairsInOneQuery(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("q"))
+		w.Write(buildSearchPayload("abc123", "Match Thread: Test vs Test"))
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(srv).FindThreads(ThreadQuery{
+		Subreddit: "soccer",
+		Flairs:    []string{"Match Thread", "Post Match Thread"},
+		Limit:     10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `flair:"Match Thread" OR flair:"Post Match Thread"`
+	if len(queries) != 1 || queries[0] != want {
+		t.Errorf("queries = %q, want one query %q", queries, want)
+	}
+}
+
+func TestFindThreadsMergesSubredditsDeterministically(t *testing.T) {
+	now := float64(time.Now().Unix())
+	post := func(id string, age float64, score int) thing {
+		data, _ := json.Marshal(postData{ID: id, Title: id, Permalink: "/r/soccer/comments/" + id + "/", CreatedUTC: now - age, Score: score})
+		return thing{Kind: "t3", Data: data}
+	}
+	bySubreddit := map[string][]thing{
+		"/r/soccer/search.json":   {post("old", 600, 5), post("tied_low", 60, 1)},
+		"/r/football/search.json": {post("new", 10, 0), post("tied_high", 60, 9), post("old", 600, 5)},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(listing{Data: listingData{Children: bySubreddit[r.URL.Path]}})
+	}))
+	defer srv.Close()
+
+	threads, err := newTestClient(srv).FindThreads(ThreadQuery{
+		Subreddits: []string{"soccer", "football"},
+		Flairs:     []string{"Match Thread"},
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, th := range threads {
+		ids = append(ids, th.ID)
+	}
+	if want := "new tied_high tied_low old"; strings.Join(ids, " ") != want {
+		t.Errorf("order = %v, want %s", ids, want)
+	}
+}
+
+func TestFindThreadsFollowsAfterCursor(t *testing.T) {
+	now := float64(time.Now().Unix())
+	post := func(id, title string) thing {
+		data, _ := json.Marshal(postData{ID: id, Title: title, Permalink: "/r/soccer/comments/" + id + "/", CreatedUTC: now})
+		return thing{Kind: "t3", Data: data}
+	}
+	pages := map[string]listingData{
+		"":     {Children: []thing{post("a", "Post Match Thread"), post("b", "Match Thread: A vs B")}, After: "t3_b"},
+		"t3_b": {Children: []thing{post("c", "Post Match Thread"), post("d", "Match Thread: C vs D")}, After: "t3_d"},
+		"t3_d": {Children: []thing{post("e", "Match Thread: E vs F")}, After: "t3_e"},
+	}
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		requests = append(requests, after)
+		json.NewEncoder(w).Encode(listing{Data: pages[after]})
+	}))
+	defer srv.Close()
+
+	threads, err := newTestClient(srv).FindThreads(ThreadQuery{
+		Subreddit:           "soccer",
+		Flairs:              []string{"match thread"},
+		Limit:               2,
+		TitleMustNotContain: []string{"post match"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 2 || threads[0].ID != "b" || threads[1].ID != "d" {
+		t.Errorf("threads = %+v, want b and d", threads)
+	}
+	if strings.Join(requests, ",") != ",t3_b" {
+		t.Errorf("pages requested = %q, want the first two", requests)
+	}
+}
+
+func TestFindThreadsSearchesEverySubreddit(t *testing.T) {
+	now := float64(time.Now().Unix())
+	bySub := map[string][]thing{}
+	for sub, ids := range map[string][]string{"soccer": {"a", "shared"}, "football": {"b", "shared"}} {
+		for i, id := range ids {
+			data, _ := json.Marshal(postData{ID: id, Title: id, Subreddit: sub, CreatedUTC: now - float64(i)})
+			bySub["/r/"+sub+"/search.json"] = append(bySub["/r/"+sub+"/search.json"], thing{Kind: "t3", Data: data})
+		}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(listing{Data: listingData{Children: bySub[r.URL.Path]}})
+	}))
+	defer srv.Close()
+
+	threads, err := newTestClient(srv).FindThreads(ThreadQuery{
+		Subreddits: []string{"soccer", "football"},
+		Flairs:     []string{"Match Thread"},
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	subs := map[string]string{}
+	for _, th := range threads {
+		subs[th.ID] = th.Subreddit
+	}
+	if len(threads) != 3 || subs["a"] != "soccer" || subs["b"] != "football" {
+		t.Errorf("threads = %+v, want a, b and shared once", threads)
+	}
+}
+
+func TestListSubreddits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.Split(r.URL.Path, "/")[2]
+		w.Write(buildSearchPayload(id, "Daily "+id))
+	}))
+	defer srv.Close()
+
+	threads, err := newTestClient(srv).ListSubreddits([]string{"soccer", "football", "soccer"}, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 2 {
+		t.Errorf("threads = %+v, want one per subreddit", threads)
+	}
+}
+
+func TestListSubreddit(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write(buildSearchPayload("t1", "Daily Discussion"))
+	}))
+	defer srv.Close()
+
+	threads, err := newTestClient(srv).ListSubreddit("/r/soccer/", 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/r/soccer/new.json" {
+		t.Errorf("path = %q, want /r/soccer/new.json", gotPath)
+	}
+	if len(threads) != 1 || threads[0].Title != "Daily Discussion" || threads[0].Type != "subreddit" {
+		t.Errorf("unexpected threads: %+v", threads)
+	}
+}
+
+func TestFindThreadsTitleFilter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(buildSearchPayload("abc123", "Post Match Thread: Test vs Test"))
+	}))
+	defer srv.Close()
+
+	threads, err := newTestClient(srv).FindThreads(ThreadQuery{
+		Type:                "match",
+		Subreddit:           "soccer",
+		Flairs:              []string{"match thread"},
+		Limit:               10,
+		TitleMustNotContain: []string{"post match"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(threads) != 0 {
+		t.Errorf("expected filtered thread to be excluded, got %+v", threads)
+	}
+}
+
+// — CapComments —
+
+func TestCapCommentsDropsOldestRootSubtree(t *testing.T) {
+	comments := []Comment{
+		{ID: "a", CreatedUTC: 100},
+		{ID: "a1", ParentID: "a", CreatedUTC: 150},
+		{ID: "b", CreatedUTC: 200},
+		{ID: "b1", ParentID: "b", CreatedUTC: 250},
+		{ID: "c", CreatedUTC: 300},
+	}
+
+	got := CapComments(comments, 3)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 comments, got %d: %+v", len(got), got)
+	}
+	for _, c := range got {
+		if c.ID == "a" || c.ID == "a1" {
+			t.Errorf("oldest root subtree should be dropped, found %q", c.ID)
+		}
+	}
+}
+
+func TestCapCommentsKeepsNewestRoot(t *testing.T) {
+	comments := []Comment{
+		{ID: "a", CreatedUTC: 100},
+		{ID: "a1", ParentID: "a", CreatedUTC: 150},
+		{ID: "a2", ParentID: "a1", CreatedUTC: 160},
+	}
+	if got := CapComments(comments, 1); len(got) != 3 {
+		t.Errorf("single root should never be dropped, got %d comments", len(got))
+	}
+}
+
+func TestCapCommentsDisabled(t *testing.T) {
+	comments := []Comment{{ID: "a"}, {ID: "b"}}
+	if got := CapComments(comments, 0); len(got) != 2 {
+		t.Errorf("max=0 should disable the cap, got %d comments", len(got))
+	}
+}
+
+// — user agent rotation —
+
+func TestNextUserAgentSingle(t *testing.T) {
+	c := NewClient("only")
+	for i := 0; i < 3; i++ {
+		if got := c.nextUserAgent(); got != "only" {
+			t.Errorf("nextUserAgent() = %q, want %q", got, "only")
+		}
+	}
+}
+
+func TestNextUserAgentRotates(t *testing.T) {
+	c := NewClient("default")
+	c.SetUserAgents([]string{"a", " ", "b"})
+	want := []string{"a", "b", "a", "b"}
+	for i, w := range want {
+		if got := c.nextUserAgent(); got != w {
+			t.Errorf("call %d: nextUserAgent() = %q, want %q", i, got, w)
+		}
+	}
+
+	c.SetUserAgents(nil)
+	if got := c.nextUserAgent(); got != "default" {
+		t.Errorf("after reset nextUserAgent() = %q, want %q", got, "default")
+	}
+}
+
+// — edited field —
+
+func TestEditedFieldUnmarshal(t *testing.T) {
+	cases := []struct {
+		raw    string
+		edited bool
+		at     float64
+	}{
+		{`false`, false, 0},
+		{`null`, false, 0},
+		{`true`, true, 0},
+		{`1700000123.0`, true, 1700000123},
+	}
+	for _, tc := range cases {
+		var c redditComment
+		if err := json.Unmarshal([]byte(`{"id":"c1","edited":`+tc.raw+`}`), &c); err != nil {
+			t.Errorf("edited=%s: unexpected error: %v", tc.raw, err)
+			continue
+		}
+		if c.Edited.Edited != tc.edited || c.Edited.At != tc.at {
+			t.Errorf("edited=%s: got %+v, want edited=%v at=%v", tc.raw, c.Edited, tc.edited, tc.at)
+		}
+	}
+}
+
+func TestProcessCommentEdited(t *testing.T) {
+	c := NewClient("test")
+	raw := []byte(`{"id":"c1","author":"a","body":"2-1 now","parent_id":"t3_post1","edited":1700000123}`)
+	var out []Comment
+	c.processComment(raw, "post1", 0, &out)
+	if len(out) != 1 || !out[0].Edited || out[0].EditedUTC != 1700000123 {
+		t.Errorf("unexpected comment: %+v", out)
+	}
+}
+
+// — depth limit —
+
+func TestProcessCommentMaxDepth(t *testing.T) {
+	c := NewClient("test")
+	c.SetMaxDepth(1)
+
+	grandchild := []byte(`{"id":"c3","author":"c","body":"deep","parent_id":"t1_c2","replies":""}`)
+	child, _ := json.Marshal(map[string]any{
+		"id": "c2", "author": "b", "body": "reply", "parent_id": "t1_c1",
+		"replies": listing{Data: listingData{Children: []thing{{Kind: "t1", Data: grandchild}}}},
+	})
+	root, _ := json.Marshal(map[string]any{
+		"id": "c1", "author": "a", "body": "root", "parent_id": "t3_post1",
+		"replies": listing{Data: listingData{Children: []thing{{Kind: "t1", Data: child}}}},
+	})
+
+	var out []Comment
+	c.processComment(root, "post1", 0, &out)
+
+	if len(out) != 2 {
+		t.Fatalf("expected root and one reply, got %d: %+v", len(out), out)
+	}
+	if got := out[1].MoreChildren; len(got) != 1 || got[0] != "c3" {
+		t.Errorf("cut-off reply MoreChildren = %v, want [c3]", got)
+	}
+}
+
+func TestAuthStateChangeNotifies(t *testing.T) {
+	c := NewClient("test")
+	if got := c.AuthState(); got != AuthAnonymous {
+		t.Fatalf("new client AuthState = %v, want anonymous", got)
+	}
+	var calls []AuthState
+	c.OnAuthChange(func(s AuthState) { calls = append(calls, s) })
+	c.setAuthState(AuthAuthenticated)
+	c.setAuthState(AuthAuthenticated)
+	c.setAuthState(AuthExpired)
+	if len(calls) != 2 || calls[0] != AuthAuthenticated || calls[1] != AuthExpired {
+		t.Errorf("notifications = %v, want [authenticated auth expired]", calls)
+	}
+}
+
+func TestProcessCommentScoreHidden(t *testing.T) {
+	c := NewClient("test")
+	raw := json.RawMessage(`{"id":"c1","author":"x","body":"hi","parent_id":"t3_post1","score":1,"score_hidden":true}`)
+	var out []Comment
+	c.processComment(raw, "post1", 0, &out)
+	if len(out) != 1 || !out[0].ScoreHidden {
+		t.Errorf("expected ScoreHidden to be carried over, got %+v", out)
+	}
+}
+
+func TestOfflineCacheFallback(t *testing.T) {
+	fail := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write(buildCommentsPayload("post1", "Match Thread", "hello"))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	c.SetCacheDir(t.TempDir())
+	if _, _, err := c.FetchComments("/r/test/comments/post1/thread"); err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+
+	fail = true
+	comments, post, err := c.FetchComments("/r/test/comments/post1/thread")
+	if err != nil {
+		t.Fatalf("a cached copy should be returned without an error, got %v", err)
+	}
+	if post.Offline == nil || post.Offline.FetchedAt.IsZero() || post.Offline.Err == nil {
+		t.Fatalf("expected Post.Offline to describe the cached copy, got %+v", post.Offline)
+	}
+	if len(comments) != 1 || post.ID != "post1" {
+		t.Errorf("unexpected cached result: %+v %+v", comments, post)
+	}
+}
+
+func TestOfflineCacheWritesOnlyChanges(t *testing.T) {
+	c := NewClient("test")
+	c.SetCacheDir(t.TempDir())
+	const permalink = "/r/test/comments/post1/thread"
+	now := time.Now()
+	saved := func() time.Time {
+		entry, ok := c.loadCache(permalink, SortNew)
+		if !ok {
+			t.Fatal("nothing cached")
+		}
+		return entry.FetchedAt
+	}
+
+	c.saveCache(permalink, SortNew, []Comment{{ID: "c1"}}, Post{ID: "post1"}, now)
+	first := saved()
+	c.saveCache(permalink, SortNew, []Comment{{ID: "c1"}, {ID: "c2"}}, Post{ID: "post1"}, now.Add(time.Second))
+	if !saved().Equal(first) {
+		t.Error("a change within cacheWriteInterval should not be written")
+	}
+	c.saveCache(permalink, SortNew, []Comment{{ID: "c1"}}, Post{ID: "post1"}, now.Add(cacheWriteInterval))
+	if !saved().Equal(first) {
+		t.Error("an unchanged listing should not be rewritten")
+	}
+	later := now.Add(cacheWriteInterval)
+	c.saveCache(permalink, SortNew, []Comment{{ID: "c1"}, {ID: "c2"}}, Post{ID: "post1"}, later)
+	if !saved().Equal(later) {
+		t.Error("a changed listing should be written once the interval has passed")
+	}
+}
+
+func TestPruneCache(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	touch := func(name string, age time.Duration) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, now.Add(-age), now.Add(-age)); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	old := touch("old.json", cacheMaxAge+time.Hour)
+	tmp := touch("x.json.tmp", 0)
+	for i := 0; i < cacheMaxEntries+1; i++ {
+		touch(fmt.Sprintf("%03d.json", i), time.Duration(i)*time.Minute)
+	}
+
+	pruneCache(dir, now)
+	for _, path := range []string{old, tmp, filepath.Join(dir, fmt.Sprintf("%03d.json", cacheMaxEntries))} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s should have been pruned", filepath.Base(path))
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "000.json")); err != nil {
+		t.Errorf("the newest entry should be kept: %v", err)
+	}
+}
+
+func TestOfflineCacheNotFoundPassesThrough(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	c.SetCacheDir(t.TempDir())
+	c.saveCache("/r/test/comments/post1/thread", SortNew, []Comment{{ID: "c1"}}, Post{ID: "post1"}, time.Now())
+	if _, _, err := c.FetchComments("/r/test/comments/post1/thread"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected plain ErrNotFound, got %v", err)
+	}
+}
+
+func TestAllowedSubreddits(t *testing.T) {
+	c := NewClient("test")
+	c.SetAllowedSubreddits([]string{" r/Soccer ", "nfl", ""})
+
+	_, err := c.ThreadFromURL("https://www.reddit.com/r/politics/comments/abc123/title/")
+	if !errors.Is(err, ErrSubredditNotAllowed) {
+		t.Errorf("ThreadFromURL outside the allowlist: err = %v, want ErrSubredditNotAllowed", err)
+	}
+	if err := c.CheckSubreddit("r/politics"); !errors.Is(err, ErrSubredditNotAllowed) {
+		t.Errorf("CheckSubreddit outside the allowlist: err = %v, want ErrSubredditNotAllowed", err)
+	}
+	if err := c.CheckSubreddit(PermalinkSubreddit("/r/SOCCER/comments/abc123/title")); err != nil {
+		t.Errorf("allowed subreddit rejected: %v", err)
+	}
+
+	c.SetAllowedSubreddits(nil)
+	if err := c.CheckSubreddit("politics"); err != nil {
+		t.Errorf("empty allowlist should allow everything, got %v", err)
+	}
+}
+
+func TestProcessCommentMoreStub(t *testing.T) {
+	c := NewClient("test")
+	replyJSON, _ := json.Marshal(redditComment{ID: "c2", Body: "reply", ParentID: "t1_c1", Replies: json.RawMessage(`""`)})
+	moreJSON, _ := json.Marshal(moreData{ParentID: "t1_c1", Children: []string{"c3", "c4"}})
+	replyListing, _ := json.Marshal(listing{Data: listingData{Children: []thing{
+		{Kind: "t1", Data: replyJSON},
+		{Kind: "more", Data: moreJSON},
+	}}})
+	raw, _ := json.Marshal(redditComment{ID: "c1", Body: "hello", ParentID: "t3_post1", Replies: replyListing})
+
+	var out []Comment
+	c.processComment(raw, "post1", 0, &out)
+	if len(out) != 2 {
+		t.Fatalf("got %d comments, want 2", len(out))
+	}
+	if got := out[0].MoreChildren; len(got) != 2 || got[0] != "c3" || got[1] != "c4" {
+		t.Errorf("MoreChildren = %v, want [c3 c4]", got)
+	}
+}
+
+func TestFetchMoreComments(t *testing.T) {
+	var gotQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"json":{"errors":[],"data":{"things":[
+			{"kind":"t1","data":{"id":"c3","author":"carol","body":"late","parent_id":"t1_c1","depth":1}},
+			{"kind":"t1","data":{"id":"c5","author":"dan","body":"deeper","parent_id":"t1_c3","depth":2}},
+			{"kind":"more","data":{"parent_id":"t1_c5","children":["c6"]}},
+			{"kind":"t1","data":{"id":"c4","author":"erin","body":"[removed]","parent_id":"t1_c1","depth":1}}
+		]}}}`))
+	}))
+	defer srv.Close()
+
+	comments, err := newTestClient(srv).FetchMoreComments("t3_abc123", []string{"c3", "c4"})
+	if err != nil {
+		t.Fatalf("FetchMoreComments: %v", err)
+	}
+	if gotQuery.Get("link_id") != "t3_abc123" || gotQuery.Get("children") != "c3,c4" {
+		t.Errorf("query = %v", gotQuery)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("got %d comments, want 2 (removed one skipped): %+v", len(comments), comments)
+	}
+	if c := comments[0]; c.ParentID != "c1" || c.Depth != 1 {
+		t.Errorf("first comment = %+v, want parent c1 at depth 1", c)
+	}
+	if c := comments[1]; c.ParentID != "c3" || c.Depth != 2 || len(c.MoreChildren) != 1 || c.MoreChildren[0] != "c6" {
+		t.Errorf("second comment = %+v, want parent c3, depth 2, more [c6]", c)
+	}
+}
+
+func TestOAuthBearerAndRenewal(t *testing.T) {
+	var tokens, rejected int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/access_token" {
+			id, secret, ok := r.BasicAuth()
+			if !ok || id != "id" || secret != "secret" || r.FormValue("grant_type") != "client_credentials" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			tokens++
+			fmt.Fprintf(w, `{"access_token":"tok%d","expires_in":3600}`, tokens)
+			return
+		}
+		if r.Host != oauthHost {
+			t.Errorf("request went to %q, want %q", r.Host, oauthHost)
+		}
+		// The first token is rejected, as if revoked, to force a renewal
+		if r.Header.Get("Authorization") == "Bearer tok1" {
+			rejected++
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer tok2" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		w.Write(buildCommentsPayload("abc123", "Match Thread", "hi"))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	c.SetCredentials("id", "secret")
+	if _, _, err := c.FetchComments("/r/test/comments/abc123/thread/"); err != nil {
+		t.Fatalf("FetchComments: %v", err)
+	}
+	if tokens != 2 || rejected != 1 {
+		t.Errorf("tokens = %d, rejected = %d, want a single renewal", tokens, rejected)
+	}
+	if c.AuthState() != AuthAuthenticated {
+		t.Errorf("AuthState = %v, want authenticated", c.AuthState())
+	}
+
+	// The cached token is reused
+	if _, _, err := c.FetchComments("/r/test/comments/abc123/thread/"); err != nil {
+		t.Fatalf("FetchComments: %v", err)
+	}
+	if tokens != 2 {
+		t.Errorf("token requested again while still valid (%d tokens)", tokens)
+	}
+}
+
+func TestOAuthBadCredentialsFallBackToAnonymous(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/access_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("Authorization") != "" || r.Host == oauthHost {
+			t.Error("request without a token should stay anonymous")
+		}
+		w.Write(buildCommentsPayload("abc123", "Match Thread", "hi"))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	c.SetCredentials("id", "wrong")
+	if err := c.Authenticate(context.Background()); err == nil {
+		t.Error("Authenticate with bad credentials should fail")
+	}
+	if _, _, err := c.FetchComments("/r/test/comments/abc123/thread/"); err != nil {
+		t.Fatalf("FetchComments: %v", err)
+	}
+	if c.AuthState() != AuthExpired {
+		t.Errorf("AuthState = %v, want auth expired", c.AuthState())
+	}
+}
+
+func TestOAuthSingleTokenRequest(t *testing.T) {
+	var tokens atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/access_token" {
+			tokens.Add(1)
+			<-release
+			// An expiry shorter than tokenSlack still gives a usable token
+			fmt.Fprint(w, `{"access_token":"tok","expires_in":0}`)
+			return
+		}
+		w.Write(buildCommentsPayload("abc123", "Match Thread", "hi"))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	c.SetCredentials("id", "secret")
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.bearer(context.Background()); err != nil {
+				t.Errorf("bearer: %v", err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if _, err := c.bearer(context.Background()); err != nil {
+		t.Fatalf("bearer: %v", err)
+	}
+	if n := tokens.Load(); n != 1 {
+		t.Errorf("%d token requests, want 1 shared by every caller", n)
+	}
+}
+
+func TestOAuthFailureBacksOff(t *testing.T) {
+	var tokens atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/access_token" {
+			tokens.Add(1)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write(buildCommentsPayload("abc123", "Match Thread", "hi"))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	c.SetCredentials("id", "wrong")
+	for range 3 {
+		if _, _, err := c.FetchComments("/r/test/comments/abc123/thread/"); err != nil {
+			t.Fatalf("FetchComments: %v", err)
+		}
+	}
+	if n := tokens.Load(); n != 1 {
+		t.Errorf("%d token requests, want 1 until tokenRetryDelay passes", n)
+	}
+
+	// New credentials are tried at once
+	c.SetCredentials("id", "other")
+	c.Authenticate(context.Background())
+	if n := tokens.Load(); n != 2 {
+		t.Errorf("%d token requests after new credentials, want 2", n)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		header  string
+		attempt int
+		want    time.Duration
+	}{
+		{"", 0, time.Second},
+		{"", 2, 4 * time.Second},
+		{"", 6, maxBackoff},
+		{"7", 0, 7 * time.Second},
+		{"600", 0, maxBackoff},
+		{now.Add(5 * time.Second).Format(http.TimeFormat), 0, 5 * time.Second},
+		{"soon", 1, 2 * time.Second},
+	}
+	for _, tc := range cases {
+		if got := retryDelay(tc.header, tc.attempt, time.Second, now); got != tc.want {
+			t.Errorf("retryDelay(%q, %d) = %v, want %v", tc.header, tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestThrottledRequestRetries(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write(buildCommentsPayload("abc123", "Match Thread", "hi"))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	var backoffs int
+	c.OnBackoff(func(time.Duration) { backoffs++ })
+	if _, _, err := c.FetchComments("/r/test/comments/abc123/thread/"); err != nil {
+		t.Fatalf("FetchComments: %v", err)
+	}
+	if requests != 3 || backoffs != 2 {
+		t.Errorf("requests = %d, backoffs = %d, want 3 and 2", requests, backoffs)
+	}
+}
+
+func TestThrottledRequestGivesUp(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	_, _, err := newTestClient(srv).FetchComments("/r/test/comments/abc123/thread/")
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("err = %v, want a 503 StatusError", err)
+	}
+	if requests != maxRetries+1 {
+		t.Errorf("requests = %d, want %d", requests, maxRetries+1)
+	}
+}
+
+func TestBackoffIsShared(t *testing.T) {
+	c := NewClient("test")
+	c.backOff(time.Hour)
+	if c.Backoff() <= 0 {
+		t.Fatal("Backoff() = 0 right after backing off")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.waitBackoff(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("waitBackoff = %v, want context.Canceled while backed off", err)
+	}
+}
+
+func TestHTMLEntitiesDecoded(t *testing.T) {
+	var rawJSON string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawJSON = r.URL.Query().Get("raw_json")
+		w.Write(buildCommentsPayload("abc123", "Match Thread: Brighton &amp; Hove Albion vs Nott&#39;m Forest", "&quot;What a goal&quot; &gt; anything"))
+	}))
+	defer srv.Close()
+
+	comments, post, err := newTestClient(srv).FetchComments("/r/test/comments/abc123/thread/")
+	if err != nil {
+		t.Fatalf("FetchComments: %v", err)
+	}
+	if rawJSON != "1" {
+		t.Errorf("raw_json = %q, want 1", rawJSON)
+	}
+	if want := "Match Thread: Brighton & Hove Albion vs Nott'm Forest"; post.Title != want {
+		t.Errorf("title = %q, want %q", post.Title, want)
+	}
+	if want := `"What a goal" > anything`; comments[0].Body != want {
+		t.Errorf("body = %q, want %q", comments[0].Body, want)
+	}
+}
+
+func TestNewExportNested(t *testing.T) {
+	comments := []Comment{
+		{ID: "a", Body: "root"},
+		{ID: "b", Body: "reply", ParentID: "a", Depth: 1},
+		{ID: "c", Body: "orphan", ParentID: "gone", Depth: 1},
+	}
+	flat := NewExport(Thread{ID: "t1"}, comments, false)
+	if len(flat.Comments) != 3 || flat.Tree != nil || flat.Omitted == "" {
+		t.Errorf("flat export = %+v", flat)
+	}
+	nested := NewExport(Thread{ID: "t1"}, comments, true)
+	if len(nested.Tree) != 2 || nested.Tree[0].ID != "a" || nested.Tree[1].ID != "c" {
+		t.Fatalf("tree roots = %+v, want a and c", nested.Tree)
+	}
+	if r := nested.Tree[0].Replies; len(r) != 1 || r[0].ID != "b" {
+		t.Errorf("replies of a = %+v, want b", r)
+	}
+
+	var b strings.Builder
+	if err := WriteExport(&b, Thread{ID: "t1"}, comments, true); err != nil {
+		t.Fatal(err)
+	}
+	var round struct {
+		Tree []struct {
+			ID      string `json:"id"`
+			Replies []struct {
+				ParentID string `json:"parent_id"`
+			} `json:"replies"`
+		} `json:"tree"`
+	}
+	if err := json.Unmarshal([]byte(b.String()), &round); err != nil {
+		t.Fatal(err)
+	}
+	if len(round.Tree) != 2 || round.Tree[0].ID != "a" || round.Tree[0].Replies[0].ParentID != "a" {
+		t.Errorf("decoded export = %+v", round)
+	}
+}
+
+func TestSetTimeout(t *testing.T) {
+	c := NewClient("test")
+	if c.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("default timeout = %v, want %v", c.httpClient.Timeout, DefaultTimeout)
+	}
+	c.SetTimeout(time.Second)
+	if c.httpClient.Timeout != MinTimeout {
+		t.Errorf("timeout below the minimum = %v, want %v", c.httpClient.Timeout, MinTimeout)
+	}
+	c.SetTimeout(45 * time.Second)
+	if got := c.httpFor(listingRequest).Timeout; got != 45*time.Second {
+		t.Errorf("listing timeout without a search timeout = %v, want 45s", got)
+	}
+	c.SetSearchTimeout(time.Minute)
+	if got := c.httpFor(listingRequest).Timeout; got != time.Minute {
+		t.Errorf("listing timeout = %v, want 1m", got)
+	}
+	if got := c.httpFor(commentsRequest).Timeout; got != 45*time.Second {
+		t.Errorf("comments timeout = %v, want 45s", got)
+	}
+	c.SetTimeout(0)
+	if c.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("SetTimeout(0) = %v, want %v", c.httpClient.Timeout, DefaultTimeout)
+	}
+}
+
+func TestSetProxy(t *testing.T) {
+	c := NewClient("test")
+	if c.Transport() != http.DefaultTransport {
+		t.Error("default transport should honour the proxy environment variables")
+	}
+	if err := c.SetProxy("socks5://user:pw@127.0.0.1:1080"); err != nil {
+		t.Fatal(err)
+	}
+	transport, ok := c.Transport().(*http.Transport)
+	if !ok {
+		t.Fatalf("transport = %T, want *http.Transport", c.Transport())
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://www.reddit.com/", nil)
+	if u, err := transport.Proxy(req); err != nil || u.String() != "socks5://user:pw@127.0.0.1:1080" {
+		t.Errorf("proxy = %v, %v", u, err)
+	}
+	for _, bad := range []string{"ftp://host:21", "http://", "::nope"} {
+		if err := c.SetProxy(bad); err == nil {
+			t.Errorf("SetProxy(%q) should fail", bad)
+		}
+	}
+	if err := c.SetProxy(""); err != nil || c.Transport() != http.DefaultTransport {
+		t.Errorf("SetProxy(\"\") should restore the default transport")
+	}
+}
@@ -0,0 +1,15 @@
+// Package reddit fetches threads and comments from Reddit's public JSON
+// endpoints. It needs no API credentials and has no dependency on the
+// terminal UI, so other programs can embed it:
+//
+//	client := reddit.NewClient("my-tool/1.0")
+//	thread, err := client.ThreadFromURLContext(ctx, "https://www.reddit.com/r/soccer/comments/abc123/")
+//	if err != nil { ... }
+//	comments, post, err := client.FetchCommentsContext(ctx, thread.Permalink)
+//
+// Every request method has a Context variant that honours cancellation and
+// deadlines; the plain variants use context.Background. HTTP failures are
+// returned as *StatusError, which matches ErrNotFound, ErrForbidden and
+// ErrRateLimited with errors.Is. Thread, Post and Comment carry JSON tags
+// so results can be serialized with a stable shape.
+package reddit
@@ -0,0 +1,47 @@
+package reddit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors for use with errors.Is.
+var (
+	// ErrInvalidURL is returned when a thread URL or permalink cannot be
+	// parsed.
+	ErrInvalidURL = errors.New("invalid thread url")
+	// ErrSubredditNotAllowed is returned when a URL or subreddit is outside
+	// the allowlist set with SetAllowedSubreddits.
+	ErrSubredditNotAllowed = errors.New("subreddit not allowed")
+	// ErrNotFound matches a 404 response.
+	ErrNotFound = errors.New("not found")
+	// ErrForbidden matches a 403 response, e.g. a private or quarantined
+	// subreddit.
+	ErrForbidden = errors.New("forbidden")
+	// ErrRateLimited matches a 429 response.
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// StatusError is returned when Reddit answers with a non-200 status.
+type StatusError struct {
+	Op         string // "fetch comments" or "fetch threads"
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: http %d", e.Op, e.StatusCode)
+}
+
+// Is lets errors.Is match a StatusError against the sentinel errors.
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
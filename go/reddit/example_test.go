@@ -0,0 +1,49 @@
+package reddit_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fenneh/reddit-stream-console/reddit"
+)
+
+func ExampleClient_FetchCommentsContext() {
+	client := reddit.NewClient("example/1.0")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	thread, err := client.ThreadFromURLContext(ctx, "https://www.reddit.com/r/golang/comments/abc123/example/")
+	if err != nil {
+		log.Fatal(err)
+	}
+	comments, post, err := client.FetchCommentsContext(ctx, thread.Permalink)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s: %d comments\n", post.Title, len(comments))
+}
+
+func ExampleClient_FindThreadsContext() {
+	client := reddit.NewClient("example/1.0")
+
+	threads, err := client.FindThreadsContext(context.Background(), reddit.ThreadQuery{
+		Subreddit:   "soccer",
+		Flairs:      []string{"Match Thread"},
+		MaxAgeHours: 24,
+		Limit:       25,
+	})
+	if errors.Is(err, reddit.ErrRateLimited) {
+		log.Print("rate limited, try again later")
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, t := range threads {
+		fmt.Println(t.Title, t.Permalink)
+	}
+}
@@ -0,0 +1,58 @@
+package reddit
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// exportOmitted explains in every export which comments are missing.
+const exportOmitted = "deleted and removed comments are not included, nor are replies under them"
+
+// Export is a thread and its comments in the shape written by WriteExport.
+// Comments is flat, in thread order, with ParentID and Depth describing the
+// tree; Tree holds the same comments nested instead.
+type Export struct {
+	Thread   Thread        `json:"thread"`
+	Comments []Comment     `json:"comments,omitempty"`
+	Tree     []*ExportNode `json:"tree,omitempty"`
+	Omitted  string        `json:"omitted"`
+}
+
+// ExportNode is a comment with its replies, for nested exports.
+type ExportNode struct {
+	Comment
+	Replies []*ExportNode `json:"replies,omitempty"`
+}
+
+// NewExport bundles thread and comments. With nested set the comments are
+// arranged into Tree by ParentID; a comment whose parent is not among
+// comments becomes a root.
+func NewExport(thread Thread, comments []Comment, nested bool) Export {
+	e := Export{Thread: thread, Omitted: exportOmitted}
+	if !nested {
+		e.Comments = comments
+		return e
+	}
+	nodes := make(map[string]*ExportNode, len(comments))
+	for _, c := range comments {
+		nodes[c.ID] = &ExportNode{Comment: c}
+	}
+	for _, c := range comments {
+		node := nodes[c.ID]
+		if parent, ok := nodes[strings.TrimSpace(c.ParentID)]; ok && parent != node {
+			parent.Replies = append(parent.Replies, node)
+			continue
+		}
+		e.Tree = append(e.Tree, node)
+	}
+	return e
+}
+
+// WriteExport writes NewExport(thread, comments, nested) to w as indented
+// JSON.
+func WriteExport(w io.Writer, thread Thread, comments []Comment, nested bool) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(NewExport(thread, comments, nested))
+}
@@ -0,0 +1,370 @@
+package reddit
+
+import (
+	"encoding/json"
+	"html"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Thread identifies a Reddit submission that can be streamed.
+type Thread struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Permalink string `json:"permalink"`
+	Type      string `json:"type"` // menu item type the thread was found through
+	// Subreddit is the name of the thread's subreddit, without "r/".
+	Subreddit string `json:"subreddit,omitempty"`
+	// MediaURL points at the thread's image, gallery or video, if any.
+	MediaURL    string  `json:"media_url,omitempty"`
+	NumComments int     `json:"num_comments"`
+	Score       int     `json:"score"`
+	CreatedUTC  float64 `json:"created_utc"`
+}
+
+// Post is the submission a comment listing belongs to.
+type Post struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Author   string `json:"author,omitempty"` // "[deleted]" once the account is gone
+	SelfText string `json:"selftext,omitempty"`
+	URL      string `json:"url,omitempty"` // link target of a link post, empty for text posts
+	MediaURL string `json:"media_url,omitempty"`
+	// MoreChildren lists IDs of top-level comments Reddit left out of the
+	// listing ("load more comments"); see Client.FetchMoreComments.
+	MoreChildren []string `json:"more_children,omitempty"`
+	// Offline is set when the live fetch failed and the listing came from
+	// the offline cache (see Client.SetCacheDir).
+	Offline *OfflineCopy `json:"-"`
+}
+
+// Comment is a single comment. ParentID is empty for top-level comments.
+type Comment struct {
+	ID            string  `json:"id"`
+	Author        string  `json:"author"`
+	Body          string  `json:"body"`
+	CreatedUTC    float64 `json:"created_utc"`
+	FormattedTime string  `json:"formatted_time,omitempty"`
+	Score         int     `json:"score"`
+	Depth         int     `json:"depth"`
+	ParentID      string  `json:"parent_id,omitempty"`
+	Edited        bool    `json:"edited,omitempty"`
+	EditedUTC     float64 `json:"edited_utc,omitempty"` // edit time, 0 when unknown or not edited
+	Stickied      bool    `json:"stickied,omitempty"`
+	// ScoreHidden is set while Reddit withholds the score of a fresh
+	// comment; Score is then 0 and meaningless.
+	ScoreHidden bool `json:"score_hidden,omitempty"`
+	// MoreChildren lists IDs of direct replies that were not loaded,
+	// either because Reddit collapsed them into a "load more comments"
+	// stub or because of the client's depth limit. Client.FetchMoreComments
+	// loads them.
+	MoreChildren []string `json:"more_children,omitempty"`
+}
+
+// CommentSort is a server-side comment order accepted by Reddit.
+type CommentSort string
+
+const (
+	SortBest          CommentSort = "confidence"
+	SortTop           CommentSort = "top"
+	SortNew           CommentSort = "new"
+	SortOld           CommentSort = "old"
+	SortControversial CommentSort = "controversial"
+	SortQA            CommentSort = "qa"
+)
+
+// Chronological reports whether the sort orders comments by time, so
+// re-sorting them locally by CreatedUTC keeps the intended order.
+func (s CommentSort) Chronological() bool {
+	return s == "" || s == SortNew || s == SortOld
+}
+
+// Label returns the name shown to users, e.g. "best" for SortBest.
+func (s CommentSort) Label() string {
+	switch s {
+	case "":
+		return string(SortNew)
+	case SortBest:
+		return "best"
+	case SortQA:
+		return "q&a"
+	}
+	return string(s)
+}
+
+// ThreadQuery describes a flair search in a subreddit.
+type ThreadQuery struct {
+	Type      string `json:"type"`
+	Subreddit string `json:"subreddit"`
+	// Subreddits, when set, are searched instead of Subreddit and their
+	// results merged.
+	Subreddits          []string `json:"subreddits,omitempty"`
+	Flairs              []string `json:"flairs"`
+	MaxAgeHours         int      `json:"max_age_hours"` // 0 = no age limit
+	Limit               int      `json:"limit"`
+	TitleMustContain    []string `json:"title_must_contain,omitempty"`
+	TitleMustNotContain []string `json:"title_must_not_contain,omitempty"`
+}
+
+// WithinAge reports whether a post created at createdUTC is young enough.
+func (q ThreadQuery) WithinAge(createdUTC float64) bool {
+	if q.MaxAgeHours == 0 {
+		return true
+	}
+	ageSeconds := float64(q.MaxAgeHours * 3600)
+	return createdUTC >= (nowUTC() - ageSeconds)
+}
+
+// TitleMatches reports whether title contains every TitleMustContain phrase
+// and none of the TitleMustNotContain phrases, ignoring case.
+func (q ThreadQuery) TitleMatches(title string) bool {
+	lower := stringsLower(title)
+	for _, phrase := range q.TitleMustContain {
+		if !stringsContains(lower, stringsLower(phrase)) {
+			return false
+		}
+	}
+	for _, phrase := range q.TitleMustNotContain {
+		if stringsContains(lower, stringsLower(phrase)) {
+			return false
+		}
+	}
+	return true
+}
+
+func nowUTC() float64 {
+	return float64(time.Now().Unix())
+}
+
+func stringsLower(value string) string {
+	return strings.ToLower(value)
+}
+
+func stringsContains(haystack, needle string) bool {
+	return strings.Contains(haystack, needle)
+}
+
+type listing struct {
+	Data listingData `json:"data"`
+}
+
+type listingData struct {
+	Children []thing `json:"children"`
+	After    string  `json:"after"` // cursor for the next page, "" on the last
+}
+
+type thing struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+// moreData is the data of a "more" thing: a stub standing in for replies
+// (or top-level comments) that were not included in a listing.
+type moreData struct {
+	ParentID string   `json:"parent_id"`
+	Children []string `json:"children"`
+}
+
+// moreChildren returns the comment IDs behind a "more" thing.
+func moreChildren(raw json.RawMessage) []string {
+	var more moreData
+	if err := json.Unmarshal(raw, &more); err != nil {
+		return nil
+	}
+	return more.Children
+}
+
+// thread converts a listing entry to a Thread found through typ.
+func (p postData) thread(typ string) Thread {
+	return Thread{
+		ID:          p.ID,
+		Title:       p.Title,
+		Permalink:   p.Permalink,
+		Type:        typ,
+		Subreddit:   p.Subreddit,
+		MediaURL:    p.mediaURL(),
+		NumComments: p.NumComments,
+		Score:       p.Score,
+		CreatedUTC:  p.CreatedUTC,
+	}
+}
+
+type postData struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Author      string  `json:"author"`
+	Subreddit   string  `json:"subreddit"`
+	SelfText    string  `json:"selftext"`
+	Permalink   string  `json:"permalink"`
+	CreatedUTC  float64 `json:"created_utc"`
+	Score       int     `json:"score"`
+	URL         string  `json:"url"`
+	IsSelf      bool    `json:"is_self"`
+	NumComments int     `json:"num_comments"`
+	IsGallery   bool    `json:"is_gallery"`
+	IsVideo     bool    `json:"is_video"`
+	PostHint    string  `json:"post_hint"`
+	Preview     *struct {
+		Images []struct {
+			Source struct {
+				URL string `json:"url"`
+			} `json:"source"`
+		} `json:"images"`
+	} `json:"preview"`
+	Media *struct {
+		RedditVideo *struct {
+			FallbackURL string `json:"fallback_url"`
+		} `json:"reddit_video"`
+	} `json:"media"`
+}
+
+// mediaURL returns the URL of the post's image, gallery or video, or ""
+// for text and plain link posts.
+func (p postData) mediaURL() string {
+	if p.IsVideo && p.Media != nil && p.Media.RedditVideo != nil && p.Media.RedditVideo.FallbackURL != "" {
+		return p.Media.RedditVideo.FallbackURL
+	}
+	isMedia := p.IsGallery || p.IsVideo
+	switch p.PostHint {
+	case "image", "hosted:video", "rich:video":
+		isMedia = true
+	}
+	if !isMedia {
+		return ""
+	}
+	if p.URL != "" {
+		return p.URL
+	}
+	if p.Preview != nil && len(p.Preview.Images) > 0 {
+		// Preview URLs come HTML-escaped (&amp;)
+		return html.UnescapeString(p.Preview.Images[0].Source.URL)
+	}
+	return ""
+}
+
+// linkURL returns where a link post points, or "" for a text post (whose
+// url is its own permalink).
+func (p postData) linkURL() string {
+	if p.IsSelf {
+		return ""
+	}
+	return p.URL
+}
+
+// unescape decodes HTML entities (&amp;, &#39;) in the post's text. The
+// client asks for raw_json=1, which should already return plain text;
+// this covers answers that ignore it.
+func (p *postData) unescape() {
+	p.Title = html.UnescapeString(p.Title)
+	p.SelfText = html.UnescapeString(p.SelfText)
+	p.URL = html.UnescapeString(p.URL)
+}
+
+type redditComment struct {
+	ID          string          `json:"id"`
+	Author      string          `json:"author"`
+	Body        string          `json:"body"`
+	CreatedUTC  float64         `json:"created_utc"`
+	Score       int             `json:"score"`
+	ScoreHidden bool            `json:"score_hidden"`
+	ParentID    string          `json:"parent_id"`
+	Depth       int             `json:"depth"`
+	Stickied    bool            `json:"stickied"`
+	Edited      editedField     `json:"edited"`
+	Replies     json.RawMessage `json:"replies"`
+}
+
+// editedField decodes Reddit's "edited" value, which is false for unedited
+// comments and the edit time in epoch seconds otherwise (true on some very
+// old comments).
+type editedField struct {
+	Edited bool
+	At     float64
+}
+
+func (e *editedField) UnmarshalJSON(data []byte) error {
+	*e = editedField{}
+	switch string(data) {
+	case "null", "false":
+		return nil
+	case "true":
+		e.Edited = true
+		return nil
+	}
+	var at float64
+	if err := json.Unmarshal(data, &at); err != nil {
+		return err
+	}
+	e.Edited = true
+	e.At = at
+	return nil
+}
+
+func (e editedField) MarshalJSON() ([]byte, error) {
+	if !e.Edited {
+		return []byte("false"), nil
+	}
+	if e.At == 0 {
+		return []byte("true"), nil
+	}
+	return json.Marshal(e.At)
+}
+
+// CapComments limits comments to at most max entries by dropping the oldest
+// root comments together with their replies, so the remaining tree stays
+// intact. The newest root is always kept. A max of zero or less disables the
+// cap. The relative order of the kept comments is preserved.
+func CapComments(comments []Comment, max int) []Comment {
+	if max <= 0 || len(comments) <= max {
+		return comments
+	}
+
+	byID := make(map[string]Comment, len(comments))
+	for _, c := range comments {
+		byID[c.ID] = c
+	}
+	rootOf := func(c Comment) string {
+		for steps := 0; c.ParentID != "" && steps < len(comments); steps++ {
+			parent, ok := byID[c.ParentID]
+			if !ok {
+				break
+			}
+			c = parent
+		}
+		return c.ID
+	}
+
+	rootIDs := make([]string, len(comments))
+	sizes := make(map[string]int)
+	var roots []Comment
+	for i, c := range comments {
+		root := rootOf(c)
+		rootIDs[i] = root
+		if _, ok := sizes[root]; !ok {
+			roots = append(roots, byID[root])
+		}
+		sizes[root]++
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		return roots[i].CreatedUTC < roots[j].CreatedUTC
+	})
+
+	total := len(comments)
+	dropped := make(map[string]bool)
+	for _, root := range roots[:len(roots)-1] {
+		if total <= max {
+			break
+		}
+		dropped[root.ID] = true
+		total -= sizes[root.ID]
+	}
+
+	out := make([]Comment, 0, total)
+	for i, c := range comments {
+		if !dropped[rootIDs[i]] {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,108 @@
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// moreChildrenBatch is the most comment IDs api/morechildren accepts per
+// request.
+const moreChildrenBatch = 100
+
+// morePayload is the body of an api/morechildren.json response.
+type morePayload struct {
+	JSON struct {
+		Errors [][]any `json:"errors"`
+		Data   struct {
+			Things []thing `json:"things"`
+		} `json:"data"`
+	} `json:"json"`
+}
+
+// FetchMoreComments is FetchMoreCommentsContext with a background context.
+func (c *Client) FetchMoreComments(linkID string, childIDs []string) ([]Comment, error) {
+	return c.FetchMoreCommentsContext(context.Background(), linkID, childIDs)
+}
+
+// FetchMoreCommentsContext loads the comments behind a "load more comments"
+// stub: childIDs from Comment.MoreChildren or Post.MoreChildren of the
+// thread linkID (a post ID, with or without the t3_ prefix). The result
+// includes their replies in thread order, with ParentID and Depth set the
+// same way as FetchComments, so it can be appended to the thread's
+// comments. Stubs nested in the answer are recorded on the returned
+// comments' MoreChildren.
+func (c *Client) FetchMoreCommentsContext(ctx context.Context, linkID string, childIDs []string) ([]Comment, error) {
+	linkID = strings.TrimPrefix(strings.TrimSpace(linkID), "t3_")
+	if linkID == "" {
+		return nil, fmt.Errorf("fetch more comments: empty link id")
+	}
+
+	var comments []Comment
+	for start := 0; start < len(childIDs); start += moreChildrenBatch {
+		end := min(start+moreChildrenBatch, len(childIDs))
+		batch, err := c.fetchMoreBatch(ctx, linkID, childIDs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, batch...)
+	}
+	return comments, nil
+}
+
+func (c *Client) fetchMoreBatch(ctx context.Context, linkID string, ids []string) ([]Comment, error) {
+	query := url.Values{}
+	query.Set("api_type", "json")
+	query.Set("link_id", "t3_"+linkID)
+	query.Set("children", strings.Join(ids, ","))
+	query.Set("raw_json", "1")
+	urlStr := "https://www.reddit.com/api/morechildren.json?" + query.Encode()
+
+	resp, err := c.get(ctx, "fetch more comments", urlStr, commentsRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var payload morePayload
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("decode more comments: %w", err)
+	}
+	if len(payload.JSON.Errors) > 0 {
+		return nil, fmt.Errorf("fetch more comments: %v", payload.JSON.Errors[0])
+	}
+	return c.moreThings(payload.JSON.Data.Things), nil
+}
+
+// moreThings converts the flat thing list of a morechildren answer. Reddit
+// sends parents before their replies; "more" stubs are attached to the
+// returned comment they belong to and dropped otherwise.
+func (c *Client) moreThings(things []thing) []Comment {
+	var out []Comment
+	index := make(map[string]int)
+	for _, t := range things {
+		switch t.Kind {
+		case "t1":
+			var comment redditComment
+			if err := json.Unmarshal(t.Data, &comment); err != nil {
+				continue
+			}
+			if comment.Body == "[deleted]" || comment.Body == "[removed]" {
+				continue
+			}
+			index[comment.ID] = len(out)
+			out = append(out, c.newComment(comment, comment.Depth))
+		case "more":
+			var more moreData
+			if err := json.Unmarshal(t.Data, &more); err != nil {
+				continue
+			}
+			if i, ok := index[strings.TrimPrefix(more.ParentID, "t1_")]; ok {
+				out[i].MoreChildren = append(out[i].MoreChildren, more.Children...)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SetProxy sends every request through proxyURL, an http://, https:// or
+// socks5:// URL that may carry user:password@. An empty proxyURL restores
+// the default, which honours the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+// environment variables.
+func (c *Client) SetProxy(proxyURL string) error {
+	proxyURL = strings.TrimSpace(proxyURL)
+	if proxyURL == "" {
+		c.httpClient.Transport = nil
+		return nil
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("parse proxy url: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("proxy url %q: scheme must be http, https or socks5", proxyURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("proxy url %q: missing host", proxyURL)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(u)
+	c.httpClient.Transport = transport
+	return nil
+}
+
+// Transport returns the round tripper the client's requests go through,
+// so other HTTP calls in a program can share its proxy setup.
+func (c *Client) Transport() http.RoundTripper {
+	if c.httpClient.Transport == nil {
+		return http.DefaultTransport
+	}
+	return c.httpClient.Transport
+}